@@ -0,0 +1,116 @@
+// License: MIT
+
+package sexa
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/soniakeys/unit"
+)
+
+// EqRow is a row of a catalog written by WriteCatalog.
+type EqRow struct {
+	RA  unit.RA
+	Dec unit.Angle
+}
+
+// CatalogFormat specifies the format of the rows written by WriteCatalog.
+//
+// The right ascension formats as RA does and the declination as Dec does.
+// Zero verbs mean 's'.  Sep separates the two fields of a row.  The zero
+// value means Symbols.CoordSep, or " " if that is not set.  If Sym is nil,
+// package variable Default is used.
+type CatalogFormat struct {
+	RAVerb, DecVerb rune
+	RAPrec, DecPrec int
+	Sep             string
+	Sym             *Symbols
+}
+
+// CatalogError records a value error in a row written by WriteCatalog.
+type CatalogError struct {
+	Row int   // index of the row in the rows argument
+	Err error // the value error, a *FormatError
+}
+
+func (e *CatalogError) Error() string {
+	return "Catalog row " + strconv.Itoa(e.Row) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the value error.
+func (e *CatalogError) Unwrap() error { return e.Err }
+
+// catalogBufSize is the size at which WriteCatalog writes its buffer.
+const catalogBufSize = 32 << 10
+
+// WriteCatalog writes rows to w, one line per row, formatted as specified
+// by format.
+//
+// Lines are formatted into a single buffer that is written as it fills, so
+// large catalogs can be streamed.  A row with a value that cannot be
+// formatted, such as a declination beyond ±90°, is written with asterisks as
+// by the custom formatters, and the first such error is returned as a
+// *CatalogError once all rows are written.  An error from w stops the
+// writing and is returned as is.
+//
+// An invalid verb or precision in format writes nothing and gives an error
+// wrapping ErrBadVerb or ErrBadPrec.
+func WriteCatalog(w io.Writer, rows []EqRow, format CatalogFormat) error {
+	sym := format.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	raVerb, decVerb := format.RAVerb, format.DecVerb
+	if raVerb == 0 {
+		raVerb = 's'
+	}
+	if decVerb == 0 {
+		decVerb = 's'
+	}
+	if err := sym.checkFormat(raVerb, format.RAPrec); err != nil {
+		return err
+	}
+	if err := sym.checkFormat(decVerb, format.DecPrec); err != nil {
+		return err
+	}
+	sep := format.Sep
+	if sep == "" {
+		sep = sym.CoordSep
+		if sep == "" {
+			sep = " "
+		}
+	}
+	var first error
+	f := &fmtState{buf: make([]byte, 0, catalogBufSize+256)}
+	ra := RA{Sym: sym}
+	dec := Dec{Sym: sym}
+	for i, row := range rows {
+		ra.RA, dec.Angle = row.RA, row.Dec
+		f.prec = format.RAPrec
+		ra.Format(f, raVerb)
+		f.buf = append(f.buf, sep...)
+		f.prec = format.DecPrec
+		dec.Format(f, decVerb)
+		f.buf = append(f.buf, '\n')
+		if first == nil {
+			if ra.Err != nil {
+				first = &CatalogError{i, ra.Err}
+			} else if dec.Err != nil {
+				first = &CatalogError{i, dec.Err}
+			}
+		}
+		if len(f.buf) >= catalogBufSize {
+			if _, err := w.Write(f.buf); err != nil {
+				return err
+			}
+			f.buf = f.buf[:0]
+		}
+	}
+	if len(f.buf) > 0 {
+		if _, err := w.Write(f.buf); err != nil {
+			return err
+		}
+	}
+	return first
+}
@@ -0,0 +1,87 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleWriteCatalog() {
+	rows := []sexa.EqRow{
+		{unit.NewRA(5, 55, 10.3), unit.NewAngle(' ', 7, 24, 25)},
+		{unit.NewRA(6, 45, 8.9), unit.NewAngle('-', 16, 42, 58)},
+	}
+	err := sexa.WriteCatalog(os.Stdout, rows, sexa.CatalogFormat{
+		RAPrec: 1,
+		Sep:    "\t",
+		Sym:    sexa.ASCII,
+	})
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// 5h55m10.3s	+07d24m25s
+	// 6h45m8.9s	-16d42m58s
+}
+
+func TestWriteCatalog(t *testing.T) {
+	rows := make([]sexa.EqRow, 3000)
+	for i := range rows {
+		rows[i] = sexa.EqRow{unit.RAFromHour(float64(i%24) + .5), unit.AngleFromDeg(45)}
+	}
+	rows[1234].Dec = unit.AngleFromDeg(100)
+	rows[2345].Dec = unit.AngleFromDeg(-100)
+	var b strings.Builder
+	err := sexa.WriteCatalog(&b, rows, sexa.CatalogFormat{RAVerb: 'm', DecVerb: 'h'})
+	var ce *sexa.CatalogError
+	if !errors.As(err, &ce) || ce.Row != 1234 || !errors.Is(err, sexa.ErrOutOfRange) {
+		t.Fatal(err)
+	}
+	lines := strings.Split(b.String(), "\n")
+	if len(lines) != len(rows)+1 || lines[len(rows)] != "" {
+		t.Fatal(len(lines))
+	}
+	if lines[1] != "1ʰ30ᵐ +45°" {
+		t.Errorf("got %q", lines[1])
+	}
+	if l := lines[1234]; !strings.HasPrefix(l, "10ʰ30ᵐ ") ||
+		!sexa.IsOverflowOutput(strings.TrimPrefix(l, "10ʰ30ᵐ "), nil) {
+		t.Errorf("got %q", l)
+	}
+	// write errors stop the writing
+	if err := sexa.WriteCatalog(failWriter{}, rows, sexa.CatalogFormat{}); err != errWrite {
+		t.Fatal(err)
+	}
+	if err := sexa.WriteCatalog(&b, nil, sexa.CatalogFormat{}); err != nil {
+		t.Fatal(err)
+	}
+	// invalid formats write nothing
+	b.Reset()
+	err = sexa.WriteCatalog(&b, rows, sexa.CatalogFormat{DecPrec: 16})
+	if b.Len() != 0 || !errors.Is(err, sexa.ErrBadPrec) || errors.As(err, &ce) {
+		t.Fatal(b.Len(), err)
+	}
+	err = sexa.WriteCatalog(&b, rows, sexa.CatalogFormat{RAVerb: 'q'})
+	if b.Len() != 0 || !errors.Is(err, sexa.ErrBadVerb) {
+		t.Fatal(b.Len(), err)
+	}
+}
+
+func BenchmarkWriteCatalog(b *testing.B) {
+	rows := make([]sexa.EqRow, 1000)
+	for i := range rows {
+		rows[i] = sexa.EqRow{unit.RAFromHour(float64(i) / 50), unit.AngleFromDeg(float64(i)/20 - 25)}
+	}
+	format := sexa.CatalogFormat{RAPrec: 2, DecPrec: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = sexa.WriteCatalog(io.Discard, rows, format)
+	}
+}
@@ -0,0 +1,92 @@
+// License: MIT
+
+package sexa
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/soniakeys/unit"
+)
+
+// Clock represents a Time formattable as a clock reading, as in 01:02:03.
+//
+// Clock formats as Time does, with colons in place of the unit symbols and
+// with the '#' and '0' flags implied, so minutes and seconds always have two
+// digits.  Without a width, hours are also padded to two digits, or take as
+// many digits as they need.  Positive values have no sign unless the '+' or
+// ' ' flag is given, and negative values have a leading '-'.
+//
+// With %s the result is HH:MM:SS, with precision as HH:MM:SS.s, and with %m
+// HH:MM.  Hours do not wrap at 24, and the DayUnit and TotalWidth of Sym are
+// ignored.  Other symbols, such as DecSep and Rounding, apply.
+type Clock struct {
+	unit.Time
+	Sym *Symbols
+	Err error // set each time the value is formatted.
+}
+
+// FmtClock constructs a formattable Clock containing the value t.
+func FmtClock(t unit.Time) *Clock { return &Clock{Time: t} }
+
+// FmtClock constructs a formattable Clock containing the value t.
+func (sym *Symbols) FmtClock(t unit.Time) *Clock { return &Clock{t, sym, nil} }
+
+// Format implements fmt.Formatter
+func (c *Clock) Format(f fmt.State, verb rune) {
+	sym := c.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	sym = sym.Clone()
+	// colons separate segments; nothing follows the last one.
+	flags := flagSharp | flagZero
+	switch verb {
+	case minAppend, minCombine, minInsert:
+		sym.HMSUnits = UnitSymbols{":", "", ""}
+	case hrDegAppend, hrDegCombine, hrDegInsert:
+		// a single segment.  '#' would only force a decimal separator.
+		sym.HMSUnits = UnitSymbols{}
+		flags = flagZero
+	default:
+		sym.HMSUnits = UnitSymbols{":", ":", ""}
+	}
+	sym.DayUnit = ""
+	sym.TotalWidth = false
+	s := state{
+		verb:      verb,
+		hrDeg:     c.Hour(),
+		caller:    fsTime,
+		sym:       sym,
+		noSignPad: true,
+	}
+	s.fromFmt(f)
+	s.flags |= flags
+	if s.widthOK {
+		c.Err = s.writeFormatted()
+		return
+	}
+	// pad hours to two digits, or if they need more, format them unpadded.
+	var b bytes.Buffer
+	s0 := s
+	s.w, s.width, s.widthOK = &b, 2, true
+	c.Err = s.writeFormatted()
+	if errors.Is(c.Err, ErrHourOverflow) {
+		b.Reset()
+		s = s0
+		s.w = &b
+		c.Err = s.writeFormatted()
+	}
+	f.Write(b.Bytes())
+}
+
+// String implements fmt.Stringer
+func (c *Clock) String() string { return fmt.Sprintf("%s", c) }
+
+// StringPrec formats c as String does, with precision prec.
+func (c *Clock) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	c.Format(f, 's')
+	return string(f.buf)
+}
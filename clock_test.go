@@ -0,0 +1,66 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleFmtClock() {
+	c := sexa.FmtClock(unit.NewTime(' ', 1, 2, 3.4))
+	fmt.Println(c)
+	fmt.Printf("%.1s  %m  %3s\n", c, c, c)
+	c.Time = unit.NewTime('-', 0, 4, 5)
+	fmt.Println(c)
+	// Output:
+	// 01:02:03
+	// 01:02:03.4  01:02  001:02:03
+	// -00:04:05
+}
+
+func TestClock(t *testing.T) {
+	for _, tc := range []struct {
+		t       unit.Time
+		f, want string
+	}{
+		{0, "%s", "00:00:00"},
+		{unit.NewTime(' ', 0, 0, 59.6), "%s", "00:01:00"},
+		{unit.NewTime(' ', 23, 59, 59.96), "%.1s", "24:00:00.0"},
+		{unit.NewTime(' ', 123, 4, 5), "%s", "123:04:05"},
+		{unit.NewTime('-', 1, 2, 3), "%s", "-01:02:03"},
+		{unit.NewTime(' ', 1, 2, 3), "%+s", "+01:02:03"},
+		{unit.NewTime(' ', 1, 2, 3), "% s", " 01:02:03"},
+		{unit.NewTime(' ', 1, 2, 30), "%.1m", "01:02.5"},
+		{unit.NewTime(' ', 1, 30, 0), "%.2h", "01.50"},
+		// negative values rounding to zero have no sign
+		{unit.Time(-.2), "%s", "00:00:00"},
+	} {
+		if got := fmt.Sprintf(tc.f, sexa.FmtClock(tc.t)); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	// symbols apply, except for units
+	sym := sexa.DefaultSymbols()
+	sym.DecSep = ","
+	sym.DayUnit = "ᵈ"
+	sym.TotalWidth = true
+	if got := sym.FmtClock(unit.NewTime(' ', 25, 0, 1.5)).StringPrec(1); got != "25:00:01,5" {
+		t.Errorf("got %q", got)
+	}
+	c := sexa.FmtClock(unit.Time(math.Inf(1)))
+	if got := c.String(); !sexa.IsOverflowOutput(got, nil) ||
+		!errors.Is(c.Err, sexa.ErrPosInf) {
+		t.Errorf("got %q, %v", got, c.Err)
+	}
+	c.Time = unit.NewTime(' ', 100, 0, 0)
+	if got := fmt.Sprintf("%2s", c); got != "********" ||
+		!errors.Is(c.Err, sexa.ErrHourOverflow) {
+		t.Errorf("got %q, %v", got, c.Err)
+	}
+}
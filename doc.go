@@ -42,6 +42,9 @@
 //
 // 1°23′45″.6
 //
+// Symbols.DoubleUnit additionally repeats the unit at the end of the number
+// for the combined and inserted conventions, as in 1°23′45″̣6″.
+//
 //   * Footnote about combining dot.  The combining dot only looks right
 //     to the extent that software (such as fonts and browsers) can render it.
 //     See http://www.unicode.org/faq/char_combmark.html#12b for a description
@@ -80,22 +83,88 @@
 //
 // Also %v is equivalent to %s.
 //
+// Without a decimal separator there is nothing to combine with or insert
+// ahead of, so at precision 0 the combined and inserted verbs format exactly
+// as the corresponding following verbs, %c as %s for example.  The same holds
+// when Symbols.TrimTrailingZeros removes all decimal places.  The '#' flag,
+// which forces the separator of a single segment format, is the exception.
+//
+// The verb %g picks the fewest segments that show the value exactly at the
+// precision, with the decimal unit following.  It is %h if the minutes and
+// seconds are zero, %m if the seconds are zero, and %s otherwise.  The #
+// flag disables the compaction, giving %s.
+//
+// The verbs %S and %M format the value as a single segment of total seconds
+// or total minutes, with the decimal unit following, as in 45296.4″ or
+// 754.94′.  Note the distinction from the decimal minutes format of %m, %n,
+// and %o, which has an hour or degree segment and a minutes segment of less
+// than 60.  %M has only a minutes segment, which can exceed 60.
+//
+// The verb %u formats all three segments without unit symbols, separated by
+// Symbols.SegSep, a space by default, as in 1 23 45.6.  This suits programs
+// that read the fields back.
+//
+// The verb %r formats as %s, then follows the result with the value in
+// decimal hours or degrees, in parentheses, to five more decimal places, as
+// in 12°34′45.6″ (12.579333°).  This suits log lines read by people wanting
+// both forms.
+//
+// The verb %k formats whole hours or degrees with no unit symbol, padded with
+// zeros to the width, as in 037 for %3k.  It suits file names and sort keys.
+// Any precision is ignored, and the value is rounded by Symbols.Rounding.
+// There is no space for an elided sign, so non-negative values of the same
+// width sort lexically.
+//
+// The verb %t formats the value as a decimal fraction of a turn, or
+// revolution, of 360° or 24 hours, followed by Symbols.RevUnit, as in
+// 0.034931 rev.
+//
 // The following flags are supported:
 //  +   always print leading sign
 //  ' ' (space) leave space for elided + sign
 //  #   display all segments, even if 0
 //  0   pad displayed segments with leading zeros
+//  -   with a width, pad the first segment on the right rather than the left
+//
+// A + flag takes precedence over a ' ' (space) flag.  The space holds the
+// column the sign would occupy, immediately in front of the number, so
+// values with the same number of integer digits align.  To align values of
+// differing numbers of digits, specify a width.
+//
+// The sign indicators can be changed with Symbols.PosSign, NegSign, and
+// SignPad, for example to full width forms for alignment with CJK text.
+// Symbols.NegParens encloses negative values in parentheses instead, as in
+// accounting tables.
+// Symbols.TrailingSign places the sign after the value.
 //
-// A + flag takes precedence over a ' ' (space) flag.
+// The sign is also decided on the rounded value.  Negative zero, and a
+// negative value that rounds to zero at the requested precision, format as
+// zero with no negative sign, as do their hemisphere indicators.
 //
 // The # flag forces output to have all segments, even if 0.  Without it,
 // leading zero segments are elided.  (Consider formatting coordinates with #;
-// distances and durations without.)
+// distances and durations without.)  Elision is decided on the value as
+// rounded to the requested precision, so a small non-zero value that rounds
+// to zero degrees shows no degree segment.
+// If Symbols.TrimTrailingZeros is set, the # flag also keeps trailing zeros
+// of the decimal segment.
+// With the single segment verbs, such as %h, the # flag shows the decimal
+// separator even at precision 0, as in 12.° for %#.0h, so the value aligns
+// with values of higher precision.  With no digits to combine with, %#.0i
+// shows the inserted form, 12°., as %#.0j does.  With the verbs of two or
+// three segments, %s, %c, %d, %m, %n, and %o, the # flag keeps its meaning
+// of showing all segments and shows no separator at precision 0.
 //
 // The 0 flag pads with a leading zero on non-first (sexagesimal) segments.
 // If a width is specfied, the 0 flag pads with leading zeros on the first
 // (hr/deg) segment as well.
 //
+// With a width, the - flag left-justifies the value by moving the padding of
+// the first segment to the end of the result.  The sign stays immediately in
+// front of the number.  As with package fmt, the - flag takes precedence
+// over the 0 flag for the first segment.  Later segments are still padded
+// with zeros.
+//
 // For the RA type, sign formatting flags '+' and ' ' are ignored.
 //
 // Specifying width forces a fixed width format.  Flag '#' is implied, ' ' is
@@ -103,6 +172,8 @@
 // given.  The width number specifies the number of digits in the integer part
 // of the most significant segment, hours or degrees — not the total width.
 // For example you would typically use the number 2 for RA, 3 for longitude.
+// To have the width number instead specify the total width of the result, set
+// Symbols.TotalWidth.
 // Also with fixed width consider avoiding the combining dot verbs unless you
 // also control output rendering. (See note above on rendering of the combining
 // dot.)  With fixed width sexagesimal formats, the sign indicator is always
@@ -120,17 +191,29 @@
 // you right justify the string (the default) then at least the decimal points
 // will align.  Additionally if you use the '0' flag, then all segments after
 // the first will have fixed width so that all unit indicators will align as
-// well.
+// well.  Symbols.PadSexagesimal gives the same two-digit segments without
+// the flag.
 //
 // Errors
 //
 // A value that cannot be expressed the in the requested format represents
 // an overflow condition.  In this case, the custom formatters emit all
 // asterisks "*************" and leave a more descriptive error in the
-// Err field of the value.
+// Err field of the value.  The error is a *FormatError, recording the value,
+// width, and precision, and wrapping one of the predefined errors such as
+// ErrDegreeOverflow.  Test for these with errors.Is.
+//
+// The asterisk can be changed with Symbols.OverflowRune.  IsOverflowOutput
+// recognizes overflow output when the Err field is not available.
 //
 // If you specify width, digits of the integer part of the first segment must
-// fit in the specified width.  Larger values cause overflow.
+// fit in the specified width.  Larger values cause overflow.  This applies
+// equally to the full sexagesimal, decimal minute, and decimal hour or degree
+// verbs.  The asterisks fill the width that a value of the same format would
+// have, so that fixed width columns stay aligned.  The error is
+// ErrDegreeOverflow for Angle and ErrHourOverflow for the other types.
+// Without a width, decimal hour and degree formats can be limited with
+// Symbols.MaxIntDigits.
 //
 // Overflow also happens if more precision is requested than is represented
 // in the underlying float64.  In the case of an angle formatted with the
@@ -139,7 +222,13 @@
 // precision are possible.  At one degree, you can get 12 digits of precision
 // in the seconds segment of a full sexagesimal number, at 360 degrees,
 // you can get 9.  For all formats, an angle too large for the specified
-// precision causes overflow.
+// precision causes overflow.  The exception is a value that is exact at the
+// precision, such as a whole number of seconds.  Its digits are all
+// significant, so it formats at any precision up to 15.
+//
+// Precision above 15 is a format error unless Symbols.HighPrecision is set.
+// Then precision up to 40 is allowed for values small enough to have the
+// significance, such as sub-arc second angles formatted in seconds.
 //
 // +Inf, -Inf, and NaN always cause overflow.
 //
@@ -0,0 +1,468 @@
+// License: MIT
+
+package sexa
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+var (
+	_ encoding.TextMarshaler = Angle{}
+	_ encoding.TextMarshaler = HourAngle{}
+	_ encoding.TextMarshaler = RA{}
+	_ encoding.TextMarshaler = Time{}
+
+	_ encoding.TextUnmarshaler = (*Angle)(nil)
+	_ encoding.TextUnmarshaler = (*HourAngle)(nil)
+	_ encoding.TextUnmarshaler = (*RA)(nil)
+	_ encoding.TextUnmarshaler = (*Time)(nil)
+
+	_ json.Marshaler = Angle{}
+	_ json.Marshaler = HourAngle{}
+	_ json.Marshaler = RA{}
+	_ json.Marshaler = Time{}
+
+	_ json.Unmarshaler = (*Angle)(nil)
+	_ json.Unmarshaler = (*HourAngle)(nil)
+	_ json.Unmarshaler = (*RA)(nil)
+	_ json.Unmarshaler = (*Time)(nil)
+
+	_ driver.Valuer = (*Angle)(nil)
+	_ driver.Valuer = (*HourAngle)(nil)
+	_ driver.Valuer = (*RA)(nil)
+	_ driver.Valuer = (*Time)(nil)
+
+	_ encoding.BinaryMarshaler = (*Angle)(nil)
+	_ encoding.BinaryMarshaler = (*HourAngle)(nil)
+	_ encoding.BinaryMarshaler = (*RA)(nil)
+	_ encoding.BinaryMarshaler = (*Time)(nil)
+
+	_ encoding.BinaryUnmarshaler = (*Angle)(nil)
+	_ encoding.BinaryUnmarshaler = (*HourAngle)(nil)
+	_ encoding.BinaryUnmarshaler = (*RA)(nil)
+	_ encoding.BinaryUnmarshaler = (*Time)(nil)
+
+	_ gob.GobEncoder = (*Angle)(nil)
+	_ gob.GobEncoder = (*HourAngle)(nil)
+	_ gob.GobEncoder = (*RA)(nil)
+	_ gob.GobEncoder = (*Time)(nil)
+
+	_ gob.GobDecoder = (*Angle)(nil)
+	_ gob.GobDecoder = (*HourAngle)(nil)
+	_ gob.GobDecoder = (*RA)(nil)
+	_ gob.GobDecoder = (*Time)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// The text is the same as that of String.  Note that this is rounded to
+// whole seconds, so a round trip through MarshalText and UnmarshalText is
+// lossy.  Where full precision is needed, encode the embedded unit.Angle
+// instead.
+//
+// MarshalText has a value receiver so that it applies to an Angle held by
+// value, as in a struct field encoded by package json or xml.  It does not
+// return an error.  A value that cannot be formatted, such as an infinity,
+// gives asterisks.  As the receiver is a copy, its Err field is not set.
+func (a Angle) MarshalText() ([]byte, error) { return []byte(a.String()), nil }
+
+// MarshalText implements encoding.TextMarshaler.  See Angle.MarshalText.
+func (ha HourAngle) MarshalText() ([]byte, error) {
+	return []byte(ha.String()), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.  See Angle.MarshalText.
+func (ra RA) MarshalText() ([]byte, error) { return []byte(ra.String()), nil }
+
+// MarshalText implements encoding.TextMarshaler.  See Angle.MarshalText.
+func (t Time) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// The text is parsed with the symbols of a.Sym, or Default if a.Sym is nil,
+// and stored in the embedded unit.Angle.  See Symbols.ParseAngle.
+func (a *Angle) UnmarshalText(text []byte) error {
+	sym := a.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	x, err := sym.ParseAngle(string(text))
+	if err != nil {
+		return err
+	}
+	a.Angle = x
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// See Angle.UnmarshalText.
+func (ha *HourAngle) UnmarshalText(text []byte) error {
+	sym := ha.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	x, err := sym.ParseHourAngle(string(text))
+	if err != nil {
+		return err
+	}
+	ha.HourAngle = x
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// See Angle.UnmarshalText and ParseRA.
+func (ra *RA) UnmarshalText(text []byte) error {
+	sym := ra.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	x, err := sym.ParseRA(string(text))
+	if err != nil {
+		return err
+	}
+	ra.RA = x
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// See Angle.UnmarshalText and Symbols.ParseTime.
+func (t *Time) UnmarshalText(text []byte) error {
+	sym := t.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	x, err := sym.ParseTime(string(text))
+	if err != nil {
+		return err
+	}
+	t.Time = x
+	return nil
+}
+
+// marshalJSON encodes x as a JSON number if sym.JSONNumeric is set,
+// otherwise m as a JSON string.
+func marshalJSON(sym *Symbols, x float64, m encoding.TextMarshaler) ([]byte, error) {
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	if sym.JSONNumeric {
+		return json.Marshal(x)
+	}
+	t, _ := m.MarshalText()
+	return json.Marshal(string(t))
+}
+
+// unmarshalJSON decodes a JSON string with u or a JSON number into x.
+// JSON null leaves x unchanged.
+func unmarshalJSON(data []byte, x *float64, u encoding.TextUnmarshaler) error {
+	switch {
+	case string(data) == "null":
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var t string
+		if err := json.Unmarshal(data, &t); err != nil {
+			return err
+		}
+		return u.UnmarshalText([]byte(t))
+	}
+	return json.Unmarshal(data, x)
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// By default the value is encoded as a JSON string holding the text of
+// MarshalText.  If the JSONNumeric field of a.Sym, or of Default if a.Sym is
+// nil, is true, the value is instead encoded as a JSON number, the radian
+// value of the embedded unit.Angle.  This preserves full precision.
+// An infinity or NaN cannot be encoded as a number and gives an error.
+// As with MarshalText, the receiver is a value.
+func (a Angle) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a.Sym, float64(a.Angle), a)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Either a JSON number or a JSON string is accepted, regardless of
+// JSONNumeric.  A number is the radian value.  A string is parsed as
+// with UnmarshalText.
+func (a *Angle) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, (*float64)(&a.Angle), a)
+}
+
+// MarshalJSON implements json.Marshaler.  See Angle.MarshalJSON.
+func (ha HourAngle) MarshalJSON() ([]byte, error) {
+	return marshalJSON(ha.Sym, float64(ha.HourAngle), ha)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  See Angle.UnmarshalJSON.
+func (ha *HourAngle) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, (*float64)(&ha.HourAngle), ha)
+}
+
+// MarshalJSON implements json.Marshaler.  See Angle.MarshalJSON.
+func (ra RA) MarshalJSON() ([]byte, error) {
+	return marshalJSON(ra.Sym, float64(ra.RA), ra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  See Angle.UnmarshalJSON.
+func (ra *RA) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, (*float64)(&ra.RA), ra)
+}
+
+// MarshalJSON implements json.Marshaler.  See Angle.MarshalJSON.
+// A numeric Time is encoded in seconds.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return marshalJSON(t.Sym, float64(t.Time), t)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  See Angle.UnmarshalJSON.
+// A numeric Time is in seconds.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, (*float64)(&t.Time), t)
+}
+
+// Value implements driver.Valuer.
+//
+// The value is the text of String.  A value that cannot be formatted gives
+// the error rather than the asterisks of the formatted text.
+func (a *Angle) Value() (driver.Value, error) {
+	v := a.String()
+	if a.Err != nil {
+		return nil, a.Err
+	}
+	return v, nil
+}
+
+// Value implements driver.Valuer.  See Angle.Value.
+func (ha *HourAngle) Value() (driver.Value, error) {
+	v := ha.String()
+	if ha.Err != nil {
+		return nil, ha.Err
+	}
+	return v, nil
+}
+
+// Value implements driver.Valuer.  See Angle.Value.
+func (ra *RA) Value() (driver.Value, error) {
+	v := ra.String()
+	if ra.Err != nil {
+		return nil, ra.Err
+	}
+	return v, nil
+}
+
+// Value implements driver.Valuer.  See Angle.Value.
+func (t *Time) Value() (driver.Value, error) {
+	v := t.String()
+	if t.Err != nil {
+		return nil, t.Err
+	}
+	return v, nil
+}
+
+// sqlScanner adapts a function to sql.Scanner.
+type sqlScanner func(src interface{}) error
+
+func (f sqlScanner) Scan(src interface{}) error { return f(src) }
+
+// scanSQL stores a database value in x, parsing text with u.
+func scanSQL(src interface{}, x *float64, u encoding.TextUnmarshaler) error {
+	switch v := src.(type) {
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case []byte:
+		return u.UnmarshalText(v)
+	case float64:
+		*x = v
+		return nil
+	}
+	return fmt.Errorf("Unsupported type %T", src)
+}
+
+// SQLScanner returns an sql.Scanner that stores a database value in a.
+//
+// The types cannot implement sql.Scanner directly because their Scan methods
+// implement fmt.Scanner.  Pass the result of SQLScanner to Rows.Scan
+// instead, as in rows.Scan(a.SQLScanner()).
+//
+// Accepted source values are string and []byte, parsed as with
+// UnmarshalText, and float64, taken as the radian value of the embedded
+// unit.Angle.  Unparsable text or other source types give an error and leave
+// a unchanged.
+func (a *Angle) SQLScanner() sql.Scanner {
+	return sqlScanner(func(src interface{}) error {
+		return scanSQL(src, (*float64)(&a.Angle), a)
+	})
+}
+
+// SQLScanner returns an sql.Scanner that stores a database value in ha.
+// See Angle.SQLScanner.
+func (ha *HourAngle) SQLScanner() sql.Scanner {
+	return sqlScanner(func(src interface{}) error {
+		return scanSQL(src, (*float64)(&ha.HourAngle), ha)
+	})
+}
+
+// SQLScanner returns an sql.Scanner that stores a database value in ra.
+// See Angle.SQLScanner.
+func (ra *RA) SQLScanner() sql.Scanner {
+	return sqlScanner(func(src interface{}) error {
+		return scanSQL(src, (*float64)(&ra.RA), ra)
+	})
+}
+
+// SQLScanner returns an sql.Scanner that stores a database value in t.
+// See Angle.SQLScanner.  A float64 source value is in seconds.
+func (t *Time) SQLScanner() sql.Scanner {
+	return sqlScanner(func(src interface{}) error {
+		return scanSQL(src, (*float64)(&t.Time), t)
+	})
+}
+
+// Type tags of the binary encoding.
+const (
+	tagAngle byte = 1 + iota
+	tagHourAngle
+	tagRA
+	tagTime
+)
+
+// marshalBinary encodes x following the type tag.
+func marshalBinary(tag byte, x float64) []byte {
+	b := make([]byte, 9)
+	b[0] = tag
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(x))
+	return b
+}
+
+// unmarshalBinary decodes data with the type tag into x.
+func unmarshalBinary(data []byte, tag byte, x *float64) error {
+	switch {
+	case len(data) != 9:
+		return fmt.Errorf("Binary encoding of %d bytes, want 9", len(data))
+	case data[0] != tag:
+		return fmt.Errorf("Binary encoding type tag %d, want %d", data[0], tag)
+	}
+	*x = math.Float64frombits(binary.BigEndian.Uint64(data[1:]))
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The encoding is 9 bytes, a type tag followed by the IEEE 754 value of the
+// embedded unit.Angle, big-endian.  It is exact, including infinities and
+// NaN.  The type tag is 1 for Angle, 2 for HourAngle, 3 for RA, and 4 for
+// Time.  Sym and Err are not encoded.
+func (a *Angle) MarshalBinary() ([]byte, error) {
+	return marshalBinary(tagAngle, float64(a.Angle)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// The data must be the encoding of an Angle.  To decode data of any of the
+// types, use DecodeBinary.
+func (a *Angle) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, tagAngle, (*float64)(&a.Angle))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// See Angle.MarshalBinary.
+func (ha *HourAngle) MarshalBinary() ([]byte, error) {
+	return marshalBinary(tagHourAngle, float64(ha.HourAngle)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// See Angle.UnmarshalBinary.
+func (ha *HourAngle) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, tagHourAngle, (*float64)(&ha.HourAngle))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// See Angle.MarshalBinary.
+func (ra *RA) MarshalBinary() ([]byte, error) {
+	return marshalBinary(tagRA, float64(ra.RA)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// See Angle.UnmarshalBinary.
+func (ra *RA) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, tagRA, (*float64)(&ra.RA))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// See Angle.MarshalBinary.
+func (t *Time) MarshalBinary() ([]byte, error) {
+	return marshalBinary(tagTime, float64(t.Time)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// See Angle.UnmarshalBinary.
+func (t *Time) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, tagTime, (*float64)(&t.Time))
+}
+
+// GobEncode implements gob.GobEncoder.
+//
+// Only the value is transported, exactly, as by MarshalBinary.  Sym is not
+// transported, so a decoded value has the symbols of its receiver, and Err
+// is not, as it is set each time the value is formatted.
+func (a *Angle) GobEncode() ([]byte, error) { return a.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder.  See Angle.GobEncode.
+func (a *Angle) GobDecode(data []byte) error { return a.UnmarshalBinary(data) }
+
+// GobEncode implements gob.GobEncoder.  See Angle.GobEncode.
+func (ha *HourAngle) GobEncode() ([]byte, error) { return ha.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder.  See Angle.GobEncode.
+func (ha *HourAngle) GobDecode(data []byte) error {
+	return ha.UnmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder.  See Angle.GobEncode.
+func (ra *RA) GobEncode() ([]byte, error) { return ra.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder.  See Angle.GobEncode.
+func (ra *RA) GobDecode(data []byte) error { return ra.UnmarshalBinary(data) }
+
+// GobEncode implements gob.GobEncoder.  See Angle.GobEncode.
+func (t *Time) GobEncode() ([]byte, error) { return t.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder.  See Angle.GobEncode.
+func (t *Time) GobDecode(data []byte) error { return t.UnmarshalBinary(data) }
+
+// DecodeBinary decodes the binary encoding of any of the types Angle,
+// HourAngle, RA, and Time, as identified by the type tag.  The result is
+// a *Angle, *HourAngle, *RA, or *Time, with a nil Sym.
+func DecodeBinary(data []byte) (SexaFormatter, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Binary encoding of 0 bytes, want 9")
+	}
+	var v interface {
+		SexaFormatter
+		encoding.BinaryUnmarshaler
+	}
+	switch data[0] {
+	case tagAngle:
+		v = &Angle{}
+	case tagHourAngle:
+		v = &HourAngle{}
+	case tagRA:
+		v = &RA{}
+	case tagTime:
+		v = &Time{}
+	default:
+		return nil, fmt.Errorf("Unknown binary encoding type tag %d", data[0])
+	}
+	if err := v.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,265 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func TestMarshalText(t *testing.T) {
+	for _, v := range []interface {
+		encoding.TextMarshaler
+		String() string
+	}{
+		sexa.FmtAngle(unit.NewAngle('-', 13, 47, 22)),
+		sexa.FmtHourAngle(unit.NewHourAngle('-', 1, 47, 22)),
+		sexa.FmtRA(unit.NewRA(1, 47, 22)),
+		sexa.FmtTime(unit.NewTime(' ', 0, 22, 7)),
+		sexa.FmtAngle(unit.Angle(math.Inf(1))),
+		sexa.FmtTime(unit.Time(math.NaN())),
+	} {
+		m, err := v.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := v.String(); string(m) != s {
+			t.Errorf("MarshalText %s, String %s", m, s)
+		}
+		// templates render the same text
+		var b strings.Builder
+		err = template.Must(template.New("").Parse("{{.}}")).Execute(&b, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != string(m) {
+			t.Errorf("template %s, MarshalText %s", b.String(), m)
+		}
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	type obs struct {
+		Dec sexa.Angle
+		HA  sexa.HourAngle
+		RA  sexa.RA
+		T   sexa.Time
+	}
+	in := obs{
+		Dec: sexa.Angle{Angle: unit.NewAngle('-', 13, 47, 22.4)},
+		HA:  sexa.HourAngle{HourAngle: unit.NewHourAngle('-', 1, 47, 22)},
+		RA:  sexa.RA{RA: unit.NewRA(1, 47, 22)},
+		T:   sexa.Time{Time: unit.NewTime(' ', 0, 22, 7)},
+	}
+	j, err := json.Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Dec":"-13°47′22″","HA":"-1ʰ47ᵐ22ˢ","RA":"1ʰ47ᵐ22ˢ","T":"22ᵐ7ˢ"}`
+	if string(j) != want {
+		t.Fatal(string(j))
+	}
+	var out obs
+	if err = json.Unmarshal(j, &out); err != nil {
+		t.Fatal(err)
+	}
+	// precision is lost beyond whole seconds
+	if math.Abs(out.Dec.Sec()-in.Dec.Sec()) > .5 ||
+		out.HA.HourAngle != in.HA.HourAngle ||
+		out.RA.RA != in.RA.RA || out.T.Time != in.T.Time {
+		t.Fatal(out)
+	}
+	x, err := xml.Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = obs{}
+	if err = xml.Unmarshal(x, &out); err != nil || out.RA.RA != in.RA.RA {
+		t.Fatal(string(x), err)
+	}
+	// fields held by value marshal the same when the struct is not
+	// addressable
+	for _, m := range []func(interface{}) ([]byte, error){
+		json.Marshal, xml.Marshal,
+	} {
+		b, err := m(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = obs{}
+		u := json.Unmarshal
+		if b[0] == '<' {
+			u = xml.Unmarshal
+		}
+		if err = u(b, &out); err != nil || out.RA.RA != in.RA.RA ||
+			out.T.Time != in.T.Time {
+			t.Fatal(string(b), err)
+		}
+	}
+	if j2, _ := json.Marshal(in); string(j2) != want {
+		t.Fatal(string(j2))
+	}
+	err = json.Unmarshal([]byte(`{"RA":"-1ʰ47ᵐ22ˢ"}`), &out)
+	if !errors.Is(err, sexa.ErrSign) {
+		t.Fatal(err)
+	}
+}
+
+func ExampleAngle_MarshalJSON() {
+	a := sexa.FmtAngle(unit.AngleFromDeg(180))
+	j, _ := json.Marshal(a)
+	fmt.Println(string(j))
+	a.Sym = &sexa.Symbols{JSONNumeric: true}
+	j, _ = json.Marshal(a)
+	fmt.Println(string(j))
+	// Output:
+	// "180°0′0″"
+	// 3.141592653589793
+}
+
+func TestJSONNumeric(t *testing.T) {
+	var a sexa.Angle
+	for _, j := range []string{`3.141592653589793`, `"180°0′0″"`} {
+		a.Angle = 0
+		if err := json.Unmarshal([]byte(j), &a); err != nil ||
+			math.Abs(a.Deg()-180) > 1e-12 {
+			t.Fatal(j, a.Deg(), err)
+		}
+	}
+	if err := json.Unmarshal([]byte(`null`), &a); err != nil ||
+		math.Abs(a.Deg()-180) > 1e-12 {
+		t.Fatal(a.Deg(), err)
+	}
+	if err := json.Unmarshal([]byte(`true`), &a); err == nil {
+		t.Fatal("bool accepted")
+	}
+	sym := &sexa.Symbols{JSONNumeric: true}
+	tm := sym.FmtTime(unit.Time(3723.5))
+	j, err := json.Marshal(tm)
+	if string(j) != "3723.5" || err != nil {
+		t.Fatal(string(j), err)
+	}
+	if _, err = json.Marshal(sym.FmtRA(unit.RA(math.NaN()))); err == nil {
+		t.Fatal("NaN encoded")
+	}
+}
+
+func TestSQL(t *testing.T) {
+	ra := sexa.FmtRA(unit.NewRA(12, 34, 45.6))
+	v, err := ra.Value()
+	if v != "12ʰ34ᵐ46ˢ" || err != nil {
+		t.Fatal(v, err)
+	}
+	if _, err = sexa.FmtAngle(unit.Angle(math.Inf(1))).Value(); !errors.Is(err, sexa.ErrPosInf) {
+		t.Fatal(err)
+	}
+	var got sexa.RA
+	for _, src := range []interface{}{v, []byte("12ʰ34ᵐ46ˢ"),
+		float64(unit.NewRA(12, 34, 46))} {
+		got.RA = 0
+		if err = got.SQLScanner().Scan(src); err != nil ||
+			math.Abs(got.Sec()-unit.NewRA(12, 34, 46).Sec()) > 1e-9 {
+			t.Fatal(src, got.Sec(), err)
+		}
+	}
+	for _, src := range []interface{}{"12ʰ60ᵐ", []byte("-1ʰ"), "x", nil, 3} {
+		if err = got.SQLScanner().Scan(src); err == nil {
+			t.Error(src, "accepted")
+		}
+	}
+}
+
+func ExampleDecodeBinary() {
+	ra := sexa.FmtRA(unit.NewRA(12, 34, 45.6))
+	b, _ := ra.MarshalBinary()
+	fmt.Printf("% x\n", b)
+	v, err := sexa.DecodeBinary(b)
+	fmt.Printf("%T %.1s %v\n", v, v, err)
+	// Output:
+	// 03 40 0a 58 99 9f e2 f1 e2
+	// *sexa.RA 12ʰ34ᵐ45.6ˢ <nil>
+}
+
+func TestBinary(t *testing.T) {
+	for _, x := range []float64{0, math.Copysign(0, -1), 1.2345678901234567,
+		-math.Pi, math.Inf(1), math.Inf(-1), math.NaN()} {
+		for _, v := range []interface {
+			encoding.BinaryMarshaler
+			encoding.BinaryUnmarshaler
+		}{
+			sexa.FmtAngle(unit.Angle(x)),
+			sexa.FmtHourAngle(unit.HourAngle(x)),
+			sexa.FmtRA(unit.RA(x)),
+			sexa.FmtTime(unit.Time(x)),
+		} {
+			b, err := v.MarshalBinary()
+			if err != nil || len(b) != 9 {
+				t.Fatal(b, err)
+			}
+			d, err := sexa.DecodeBinary(b)
+			if err != nil || reflect.TypeOf(d) != reflect.TypeOf(v) {
+				t.Fatalf("%T %v", d, err)
+			}
+			// exact, bit for bit
+			if b2, _ := d.(encoding.BinaryMarshaler).MarshalBinary(); string(b2) != string(b) {
+				t.Errorf("%T %g: round trip % x, % x", v, x, b2, b)
+			}
+			if err = v.UnmarshalBinary(b); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	// the tag must match the type
+	b, _ := sexa.FmtAngle(1).MarshalBinary()
+	var ra sexa.RA
+	if err := ra.UnmarshalBinary(b); err == nil {
+		t.Error("Angle decoded as RA")
+	}
+	for _, b := range [][]byte{nil, b[:8], append(b, 0), {5, 0, 0, 0, 0, 0, 0, 0, 0}} {
+		if _, err := sexa.DecodeBinary(b); err == nil {
+			t.Errorf("% x decoded", b)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type obs struct {
+		Dec sexa.Angle
+		HA  *sexa.HourAngle
+		RA  sexa.RA
+		T   sexa.Time
+	}
+	in := obs{
+		Dec: sexa.Angle{Angle: unit.NewAngle('-', 13, 47, 22.4), Sym: sexa.ASCII},
+		HA:  sexa.FmtHourAngle(unit.HourAngle(math.Inf(-1))),
+		RA:  sexa.RA{RA: unit.NewRA(1, 47, 22.123456)},
+		T:   sexa.Time{Time: unit.Time(math.NaN())},
+	}
+	_ = in.HA.String() // sets Err, which is not transported
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	var out obs
+	if err := gob.NewDecoder(&b).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Dec.Angle != in.Dec.Angle || out.Dec.Sym != nil ||
+		out.HA.HourAngle != in.HA.HourAngle || out.HA.Err != nil ||
+		out.RA.RA != in.RA.RA || !math.IsNaN(float64(out.T.Time)) {
+		t.Fatalf("%+v", out)
+	}
+}
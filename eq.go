@@ -0,0 +1,116 @@
+// License: MIT
+
+package sexa
+
+import (
+	"fmt"
+
+	"github.com/soniakeys/unit"
+)
+
+// Dec represents a formattable declination.
+//
+// Dec formats as Angle does except that the sign is mandatory, as with the
+// '+' flag, and fixed width format is the default.  Without a width, Dec
+// formats with a width of 2 and the '0' flag, as in +41°16′09″.  With
+// a width, the flags are as given, except for '+'.
+//
+// A declination beyond ±90° cannot be formatted.  The output then contains
+// asterisks and Err is a *FormatError wrapping ErrOutOfRange, as tested with
+// errors.Is(d.Err, ErrOutOfRange).
+type Dec struct {
+	unit.Angle
+	Sym *Symbols
+	Err error // set each time the value is formatted.
+}
+
+// FmtDec constructs a formattable Dec containing the value dec.
+func FmtDec(dec unit.Angle) *Dec { return &Dec{Angle: dec} }
+
+// FmtDec constructs a formattable Dec containing the value dec.
+func (sym *Symbols) FmtDec(dec unit.Angle) *Dec { return &Dec{dec, sym, nil} }
+
+// Format implements fmt.Formatter
+func (dec *Dec) Format(f fmt.State, c rune) {
+	s := state{
+		verb:   c,
+		hrDeg:  dec.Deg(),
+		caller: fsAngle,
+		sym:    dec.Sym,
+		limit:  90,
+	}
+	s.fromFmt(f)
+	// the sign is always shown, and degrees default to a width of 2
+	// with leading zeros.
+	s.flags |= flagPlus
+	if !s.widthOK {
+		s.width, s.widthOK = 2, true
+		s.flags |= flagZero
+	}
+	dec.Err = s.writeFormatted()
+}
+
+// String implements fmt.Stringer
+func (dec *Dec) String() string { return fmt.Sprintf("%s", dec) }
+
+// StringPrec formats dec as String does, with precision prec.
+func (dec *Dec) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	dec.Format(f, 's')
+	return string(f.buf)
+}
+
+// EqCoord represents formattable equatorial coordinates, right ascension and
+// declination.
+//
+// The right ascension formats as RA does and the declination as Dec does,
+// both with the same verb, flags, width, and precision.  They are separated
+// by Symbols.CoordSep.
+type EqCoord struct {
+	RA  unit.RA
+	Dec unit.Angle
+	Sym *Symbols
+	Err error // set each time the value is formatted, from either half.
+}
+
+// FmtEqCoord constructs a formattable EqCoord containing the values ra and dec.
+func FmtEqCoord(ra unit.RA, dec unit.Angle) *EqCoord {
+	return &EqCoord{RA: ra, Dec: dec}
+}
+
+// FmtEqCoord constructs a formattable EqCoord containing the values ra and
+// dec.
+func (sym *Symbols) FmtEqCoord(ra unit.RA, dec unit.Angle) *EqCoord {
+	return &EqCoord{ra, dec, sym, nil}
+}
+
+// Format implements fmt.Formatter
+func (ec *EqCoord) Format(f fmt.State, c rune) {
+	sym := ec.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	ra := RA{RA: ec.RA, Sym: sym}
+	ra.Format(f, c)
+	sep := sym.CoordSep
+	if sep == "" {
+		sep = " "
+	}
+	f.Write([]byte(sep))
+	dec := Dec{Angle: ec.Dec, Sym: sym}
+	dec.Format(f, c)
+	ec.Err = ra.Err
+	if ec.Err == nil {
+		ec.Err = dec.Err
+	}
+}
+
+// String implements fmt.Stringer
+func (ec *EqCoord) String() string { return fmt.Sprintf("%s", ec) }
+
+// StringPrec formats ec as String does, with precision prec.
+func (ec *EqCoord) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	ec.Format(f, 's')
+	return string(f.buf)
+}
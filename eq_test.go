@@ -0,0 +1,79 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleFmtDec() {
+	for _, dec := range []unit.Angle{
+		unit.NewAngle(' ', 41, 16, 9),
+		unit.NewAngle('-', 0, 30, 0),
+		unit.NewAngle(' ', 90, 0, 0),
+		unit.NewAngle(' ', 5, 6, 7.89),
+	} {
+		d := sexa.FmtDec(dec)
+		fmt.Printf("%s  %.1s  %3s\n", d, d, d)
+	}
+	// Output:
+	// +41°16′09″  +41°16′09.0″  + 41°16′ 9″
+	// -00°30′00″  -00°30′00.0″  -  0°30′ 0″
+	// +90°00′00″  +90°00′00.0″  + 90° 0′ 0″
+	// +05°06′08″  +05°06′07.9″  +  5° 6′ 8″
+}
+
+func TestDec(t *testing.T) {
+	d := sexa.FmtDec(unit.AngleFromDeg(-90.001))
+	var fe *sexa.FormatError
+	if got := d.String(); got != "**********" || !errors.Is(d.Err, sexa.ErrOutOfRange) ||
+		!errors.As(d.Err, &fe) {
+		t.Errorf("got %q, %v", got, d.Err)
+	}
+	d.Angle = unit.AngleFromDeg(12.5)
+	for _, tc := range []struct{ f, want string }{
+		{"%.1h", "+12.5°"},
+		{"%m", "+12°30′"},
+		{"%03m", "+012°30′"},
+		{"% s", "+12°30′00″"},
+	} {
+		if got := fmt.Sprintf(tc.f, d); got != tc.want || d.Err != nil {
+			t.Errorf("%s: got %q, %v want %q", tc.f, got, d.Err, tc.want)
+		}
+	}
+}
+
+func ExampleFmtEqCoord() {
+	c := sexa.FmtEqCoord(unit.NewRA(12, 34, 45), unit.NewAngle(' ', 41, 16, 9))
+	fmt.Println(c)
+	fmt.Printf("%2.1s\n", c)
+	c.Dec = unit.AngleFromDeg(-91)
+	s := c.String()
+	fmt.Println(s, c.Err)
+	// Output:
+	// 12ʰ34ᵐ45ˢ +41°16′09″
+	//  12ʰ34ᵐ45.0ˢ +41°16′ 9.0″
+	// 12ʰ34ᵐ45ˢ ********** Formatting -91 in width 2: Value out of range
+}
+
+func TestEqCoord(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits: sexa.UnitSymbols{"d", "m", "s"},
+		HMSUnits: sexa.UnitSymbols{"h", "m", "s"},
+		DecSep:   ".",
+		CoordSep: ", ",
+	}
+	c := sym.FmtEqCoord(unit.RAFromHour(25.5), unit.AngleFromDeg(-0.5))
+	if got := fmt.Sprintf("%.1m", c); got != "1h30.0m, -00d30.0m" || c.Err != nil {
+		t.Errorf("got %q, %v", got, c.Err)
+	}
+	c.RA = unit.RAFromHour(12)
+	if got := fmt.Sprintf("%1.1m", c); !errors.Is(c.Err, sexa.ErrHourOverflow) {
+		t.Errorf("got %q, %v", got, c.Err)
+	}
+}
@@ -0,0 +1,614 @@
+// License: MIT
+
+package sexa
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/soniakeys/unit"
+)
+
+// fmtState is a minimal fmt.State.  It allows the custom formatters to be
+// driven directly, without going through a Printf function of package fmt.
+type fmtState struct {
+	buf   []byte
+	wid   int
+	widOK bool
+	prec  int
+	flags string
+}
+
+func (f *fmtState) Write(b []byte) (int, error) {
+	f.buf = append(f.buf, b...)
+	return len(b), nil
+}
+
+func (f *fmtState) Width() (int, bool)     { return f.wid, f.widOK }
+func (f *fmtState) Precision() (int, bool) { return f.prec, true }
+func (f *fmtState) Flag(c int) bool        { return strings.IndexByte(f.flags, byte(c)) >= 0 }
+
+// Flag is a formatting flag for the AppendFormat methods.  Each has the
+// meaning of the corresponding flag character of a format specifier.
+type Flag byte
+
+// Flags for AppendFormat.
+const (
+	FlagPlus  Flag = '+' // always print leading sign
+	FlagSpace Flag = ' ' // leave space for elided + sign
+	FlagSharp Flag = '#' // display all segments, even if 0
+	FlagZero  Flag = '0' // pad displayed segments with leading zeros
+	FlagMinus Flag = '-' // left-justify
+)
+
+// flagSetOf returns flags as a flagSet.
+func flagSetOf(flags []Flag) flagSet {
+	var fs flagSet
+	for _, f := range flags {
+		if i := strings.IndexByte("+ #0-", byte(f)); i >= 0 {
+			fs |= 1 << i
+		}
+	}
+	return fs
+}
+
+// AppendFormat formats a with the verb, precision prec, and flags, as
+// the custom formatter would, and appends the result to b.
+//
+// It avoids the fmt package and does not allocate if b has the capacity for
+// the result.  There is no width, so flags that apply to fixed width
+// formats have no effect.  As with Format, a value error leaves asterisks in
+// the output and is stored in the Err field.
+func (a *Angle) AppendFormat(b []byte, verb rune, prec int, flags ...Flag) []byte {
+	s := state{
+		verb:   verb,
+		hrDeg:  a.Deg(),
+		prec:   prec,
+		precOK: true,
+		flags:  flagSetOf(flags),
+		caller: fsAngle,
+		sym:    a.Sym,
+	}
+	b, a.Err = s.appendFormat(b)
+	return b
+}
+
+// AppendFormat formats ha and appends the result to b.
+// See Angle.AppendFormat.
+func (ha *HourAngle) AppendFormat(b []byte, verb rune, prec int, flags ...Flag) []byte {
+	s := state{
+		verb:   verb,
+		hrDeg:  ha.Hour(),
+		prec:   prec,
+		precOK: true,
+		flags:  flagSetOf(flags),
+		caller: fsHourAngle,
+		sym:    ha.Sym,
+	}
+	b, ha.Err = s.appendFormat(b)
+	return b
+}
+
+// AppendFormat formats ra and appends the result to b.
+// See Angle.AppendFormat.
+func (ra *RA) AppendFormat(b []byte, verb rune, prec int, flags ...Flag) []byte {
+	s := state{
+		verb:   verb,
+		hrDeg:  unit.PMod(ra.Hour(), 24),
+		prec:   prec,
+		precOK: true,
+		flags:  flagSetOf(flags),
+		caller: fsRA,
+		sym:    ra.Sym,
+	}
+	b, ra.Err = s.appendFormat(b)
+	return b
+}
+
+// AppendFormat formats t and appends the result to b.
+// See Angle.AppendFormat.
+func (t *Time) AppendFormat(b []byte, verb rune, prec int, flags ...Flag) []byte {
+	s := state{
+		verb:   verb,
+		hrDeg:  t.Hour(),
+		prec:   prec,
+		precOK: true,
+		flags:  flagSetOf(flags),
+		caller: fsTime,
+		sym:    t.Sym,
+	}
+	b, t.Err = s.appendFormat(b)
+	return b
+}
+
+// WriteFormat formats a with the verb and precision prec, as the custom
+// formatter would, and writes the result to w.
+//
+// It returns the number of bytes written and any error from w.  (It is not
+// named WriteTo, which by convention implements io.WriterTo.)  As with
+// Format, a value error leaves asterisks in the output and is stored in the
+// Err field.
+func (a *Angle) WriteFormat(w io.Writer, verb rune, prec int) (int64, error) {
+	var buf [64]byte
+	n, err := w.Write(a.AppendFormat(buf[:0], verb, prec))
+	return int64(n), err
+}
+
+// WriteFormat formats ha and writes the result to w.
+// See Angle.WriteFormat.
+func (ha *HourAngle) WriteFormat(w io.Writer, verb rune, prec int) (int64, error) {
+	var buf [64]byte
+	n, err := w.Write(ha.AppendFormat(buf[:0], verb, prec))
+	return int64(n), err
+}
+
+// WriteFormat formats ra and writes the result to w.
+// See Angle.WriteFormat.
+func (ra *RA) WriteFormat(w io.Writer, verb rune, prec int) (int64, error) {
+	var buf [64]byte
+	n, err := w.Write(ra.AppendFormat(buf[:0], verb, prec))
+	return int64(n), err
+}
+
+// WriteFormat formats t and writes the result to w.
+// See Angle.WriteFormat.
+func (t *Time) WriteFormat(w io.Writer, verb rune, prec int) (int64, error) {
+	var buf [64]byte
+	n, err := w.Write(t.AppendFormat(buf[:0], verb, prec))
+	return int64(n), err
+}
+
+// AppendString formats a with the verb and precision prec, as the custom
+// formatter would, and writes the result to sb.
+//
+// The result is that of fmt.Sprintf with the same verb and precision, written
+// without an intermediate string.  As with Format, a value error leaves
+// asterisks in the output and is stored in the Err field.  An invalid verb or
+// precision writes nothing and stores a *FormatError wrapping ErrBadVerb or
+// ErrBadPrec.
+func (a *Angle) AppendString(sb *strings.Builder, verb rune, prec int) {
+	s := state{
+		w:      sb,
+		verb:   verb,
+		hrDeg:  a.Deg(),
+		prec:   prec,
+		precOK: true,
+		caller: fsAngle,
+		sym:    a.Sym,
+	}
+	if a.Err = s.checkFormat(); a.Err != nil {
+		return
+	}
+	a.Err = s.writeFormatted()
+}
+
+// AppendRunes formats a with the verb and precision prec, as the custom
+// formatter would, and appends the result to dst.
+//
+// Runes are appended individually, so a combining DecCombine rune occupies
+// an element of its own.  Callers placing runes into terminal cells can
+// decide how to render it.
+//
+// As with Format, a value error leaves asterisks in the output.  The error
+// is returned and also stored in the Err field.  An invalid verb or precision
+// appends nothing and gives a *FormatError wrapping ErrBadVerb or ErrBadPrec.
+func (a *Angle) AppendRunes(dst []rune, verb rune, prec int) ([]rune, error) {
+	s := state{verb: verb, hrDeg: a.Deg(), prec: prec, precOK: true,
+		sym: a.Sym}
+	if a.Err = s.checkFormat(); a.Err != nil {
+		return dst, a.Err
+	}
+	f := &fmtState{prec: prec}
+	a.Format(f, verb)
+	for _, r := range string(f.buf) {
+		dst = append(dst, r)
+	}
+	return dst, a.Err
+}
+
+// DecimalUnit selects a convention of unit indication on the decimal
+// segment, for FormatFlags.
+type DecimalUnit int
+
+// Decimal unit conventions, as of the verbs %s, %c, and %d.
+const (
+	UnitFollowing DecimalUnit = iota // unit follows the segment, as 45.6″
+	UnitCombined                     // unit combined with DecCombine, as 45″̣6
+	UnitInserted                     // unit before DecSep, as 45″.6
+)
+
+// FormatFlags are the components of a format specifier, other than the
+// verb and precision, for Angle.Sexagesimal.
+type FormatFlags struct {
+	ForceSign   bool        // '+' flag, always a leading sign
+	AllSegments bool        // '#' flag, display all segments, even if 0
+	ZeroPad     bool        // '0' flag, pad segments with leading zeros
+	Width       int         // width of the first segment, if greater than 0
+	DecimalUnit DecimalUnit // unit convention, selecting %s, %c, or %d
+}
+
+// Sexagesimal formats a in full sexagesimal format, with the precision prec
+// and the format components of flags, and returns the result and any value
+// error.
+//
+// It is a programmatic equivalent of a format specifier.  With flags of
+// {ForceSign: true, Width: 3, DecimalUnit: UnitCombined}, for example, the
+// result is that of fmt.Sprintf("%+3.*c", prec, a).  As with FormatAngle,
+// the error is returned rather than stored in the Err field, and a value
+// error leaves asterisks in the result.
+//
+// Where a format specifier would output "%!(BADPREC)", Sexagesimal returns
+// an empty string and an error wrapping ErrBadPrec.  A negative Width
+// likewise gives an error wrapping ErrBadWidth.
+func (a *Angle) Sexagesimal(prec int, flags FormatFlags) (string, error) {
+	sym := a.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	if !sym.validPrec(prec) {
+		return "", fmt.Errorf("%w %d", ErrBadPrec, prec)
+	}
+	if flags.Width < 0 {
+		return "", fmt.Errorf("%w %d", ErrBadWidth, flags.Width)
+	}
+	var sb strings.Builder
+	s := state{
+		w:       &sb,
+		verb:    secAppend,
+		hrDeg:   a.Deg(),
+		width:   flags.Width,
+		widthOK: flags.Width > 0,
+		prec:    prec,
+		precOK:  true,
+		caller:  fsAngle,
+		sym:     sym,
+	}
+	switch flags.DecimalUnit {
+	case UnitCombined:
+		s.verb = secCombine
+	case UnitInserted:
+		s.verb = secInsert
+	}
+	if flags.ForceSign {
+		s.flags |= flagPlus
+	}
+	if flags.AllSegments {
+		s.flags |= flagSharp
+	}
+	if flags.ZeroPad {
+		s.flags |= flagZero
+	}
+	err := s.writeFormatted()
+	return sb.String(), err
+}
+
+// appendFormatted formats hrDeg as the custom formatter for caller would,
+// appending the result to b.  An invalid verb or precision appends nothing
+// and gives a *FormatError wrapping ErrBadVerb or ErrBadPrec.
+func appendFormatted(b []byte, hrDeg float64, caller int, verb rune, prec int, sym *Symbols) ([]byte, error) {
+	s := state{
+		verb:   verb,
+		hrDeg:  hrDeg,
+		prec:   prec,
+		precOK: true,
+		caller: caller,
+		sym:    sym,
+	}
+	if err := s.checkFormat(); err != nil {
+		return b, err
+	}
+	return s.appendFormat(b)
+}
+
+// appendPlainDecimal formats hrDeg as a plain decimal number with prec
+// places, with no unit symbol, "." as the decimal separator, and "-" as the
+// sign of negative values.  Of sym, only Rounding and HighPrecision apply.
+// An invalid precision appends nothing and gives an error wrapping ErrBadPrec.
+func appendPlainDecimal(b []byte, hrDeg float64, caller int, prec int, sym *Symbols) ([]byte, error) {
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	plain := &Symbols{
+		DecSep:        ".",
+		Rounding:      sym.Rounding,
+		HighPrecision: sym.HighPrecision,
+	}
+	if !plain.validPrec(prec) {
+		return b, fmt.Errorf("%w %d", ErrBadPrec, prec)
+	}
+	return appendFormatted(b, hrDeg, caller, hrDegAppend, prec, plain)
+}
+
+// FormatAngle formats a with the verb and precision prec, returning the
+// result and any value error.
+//
+// It is a functional alternative to the custom formatter of Angle.  The error
+// is returned rather than stored, and nothing is modified, so FormatAngle is
+// safe for concurrent use as long as sym is not modified.  If sym is nil,
+// package variable Default is used.  As with the custom formatter, a value
+// error leaves asterisks in the result.
+//
+// Where the custom formatter would output "%!c(BADVERB)" or "%!(BADPREC)",
+// FormatAngle returns an empty string and a *FormatError wrapping ErrBadVerb
+// or ErrBadPrec.
+//
+// FormatAngle does not go through a Printf function of package fmt.  The
+// result of FormatAngle(a, 's', prec, nil) is that of
+// fmt.Sprintf("%.*s", prec, FmtAngle(a)).
+func FormatAngle(a unit.Angle, verb rune, prec int, sym *Symbols) (string, error) {
+	b, err := appendFormatted(nil, a.Deg(), fsAngle, verb, prec, sym)
+	return string(b), err
+}
+
+// FormatHourAngle formats h with the verb and precision prec, returning the
+// result and any value error.  See FormatAngle.
+func FormatHourAngle(h unit.HourAngle, verb rune, prec int, sym *Symbols) (string, error) {
+	b, err := appendFormatted(nil, h.Hour(), fsHourAngle, verb, prec, sym)
+	return string(b), err
+}
+
+// FormatRA formats ra with the verb and precision prec, returning the result
+// and any value error.  See FormatAngle.
+func FormatRA(ra unit.RA, verb rune, prec int, sym *Symbols) (string, error) {
+	b, err := appendFormatted(nil, unit.PMod(ra.Hour(), 24), fsRA, verb, prec, sym)
+	return string(b), err
+}
+
+// FormatTime formats t with the verb and precision prec, returning the result
+// and any value error.  See FormatAngle.
+func FormatTime(t unit.Time, verb rune, prec int, sym *Symbols) (string, error) {
+	b, err := appendFormatted(nil, t.Hour(), fsTime, verb, prec, sym)
+	return string(b), err
+}
+
+// FuncMap returns template functions formatting with sym, or with Default
+// if sym is nil.
+//
+// The functions angle, hourAngle, ra, and time take a unit.Angle,
+// unit.HourAngle, unit.RA, or unit.Time, and an optional precision, and
+// return the value formatted as with %s, as in {{angle .Dec 1}}.  A value
+// that cannot be formatted gives asterisks rather than an error.  An invalid
+// precision stops execution with an error wrapping ErrBadPrec.  The map
+// can be passed to the Funcs method of a text/template or, converted to
+// html/template.FuncMap, an html/template.
+func FuncMap(sym *Symbols) template.FuncMap {
+	return template.FuncMap{
+		"angle": func(a unit.Angle, prec ...int) (string, error) {
+			return templateResult(FormatAngle(a, 's', firstPrec(prec), sym))
+		},
+		"hourAngle": func(h unit.HourAngle, prec ...int) (string, error) {
+			return templateResult(FormatHourAngle(h, 's', firstPrec(prec), sym))
+		},
+		"ra": func(ra unit.RA, prec ...int) (string, error) {
+			return templateResult(FormatRA(ra, 's', firstPrec(prec), sym))
+		},
+		"time": func(t unit.Time, prec ...int) (string, error) {
+			return templateResult(FormatTime(t, 's', firstPrec(prec), sym))
+		},
+	}
+}
+
+// templateResult returns the result of a template function, dropping a
+// value error, which is output as asterisks.
+func templateResult(s string, err error) (string, error) {
+	if errors.Is(err, ErrBadPrec) {
+		return "", err
+	}
+	return s, nil
+}
+
+// firstPrec returns the optional precision argument of a template function.
+func firstPrec(prec []int) int {
+	if len(prec) == 0 {
+		return 0
+	}
+	return prec[0]
+}
+
+// AppendUnitAngle formats a with the verb and precision prec, appending the
+// result to b.
+//
+// It is the lean equivalent of formatting FmtAngle(a), or sym.FmtAngle(a),
+// without constructing the formattable Angle.  It does not allocate if b has
+// the capacity for the result.  If sym is nil, package
+// variable Default is used.  As with the custom formatter, a value error
+// leaves asterisks in the output.  The error is returned.  An invalid verb or
+// precision appends nothing and gives the error of FormatAngle.
+func AppendUnitAngle(b []byte, a unit.Angle, verb rune, prec int, sym *Symbols) ([]byte, error) {
+	return appendFormatted(b, a.Deg(), fsAngle, verb, prec, sym)
+}
+
+// AppendUnitHourAngle formats h with the verb and precision prec, appending
+// the result to b.  See AppendUnitAngle.
+func AppendUnitHourAngle(b []byte, h unit.HourAngle, verb rune, prec int, sym *Symbols) ([]byte, error) {
+	return appendFormatted(b, h.Hour(), fsHourAngle, verb, prec, sym)
+}
+
+// AppendUnitRA formats ra with the verb and precision prec, appending the
+// result to b.  See AppendUnitAngle.
+func AppendUnitRA(b []byte, ra unit.RA, verb rune, prec int, sym *Symbols) ([]byte, error) {
+	return appendFormatted(b, unit.PMod(ra.Hour(), 24), fsRA, verb, prec, sym)
+}
+
+// AppendUnitTime formats t with the verb and precision prec, appending the
+// result to b.  See AppendUnitAngle.
+func AppendUnitTime(b []byte, t unit.Time, verb rune, prec int, sym *Symbols) ([]byte, error) {
+	return appendFormatted(b, t.Hour(), fsTime, verb, prec, sym)
+}
+
+// runeWidth returns the number of runes in s, not counting combining marks.
+func runeWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		if !unicode.Is(unicode.Mn, r) {
+			n++
+		}
+	}
+	return n
+}
+
+// AlignAngles formats each angle of as with the verb and precision prec, and
+// right-justifies the results to the width of the widest.
+//
+// Widths are counted in characters not including combining marks, so
+// decimal points and unit symbols line up in a column of the results.
+// Angles that cannot be formatted are output as by FormatAngle, then
+// justified with the rest.
+func AlignAngles(as []unit.Angle, verb rune, prec int, sym *Symbols) []string {
+	r := make([]string, len(as))
+	w := 0
+	for i, a := range as {
+		r[i], _ = FormatAngle(a, verb, prec, sym)
+		if n := runeWidth(r[i]); n > w {
+			w = n
+		}
+	}
+	for i, s := range r {
+		r[i] = strings.Repeat(" ", w-runeWidth(s)) + s
+	}
+	return r
+}
+
+// FormatRange formats the range of angles lo to hi, as for an uncertainty
+// interval.
+//
+// Both values are formatted with the verb and precision prec and joined with
+// sym.RangeSep.  The shorter result is padded on the left with spaces so that
+// both sides have the same width.  If sym is nil, package variable Default is
+// used.
+//
+// If either value cannot be formatted, that side contains asterisks and the
+// error is returned.  An invalid verb or precision gives an empty string and
+// the error of FormatAngle.
+func FormatRange(lo, hi unit.Angle, verb rune, prec int, sym *Symbols) (string, error) {
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	l, err := FormatAngle(lo, verb, prec, sym)
+	if errors.Is(err, ErrBadVerb) || errors.Is(err, ErrBadPrec) {
+		return "", err
+	}
+	h, err2 := FormatAngle(hi, verb, prec, sym)
+	if err == nil {
+		err = err2
+	}
+	if d := runeWidth(l) - runeWidth(h); d > 0 {
+		h = strings.Repeat(" ", d) + h
+	} else {
+		l = strings.Repeat(" ", -d) + l
+	}
+	sep := sym.RangeSep
+	if sep == "" {
+		sep = " – "
+	}
+	return l + sep + h, err
+}
+
+// FormatSchedule formats each time of ts with the verb and precision prec,
+// and follows each after the first with the difference from the time before
+// it, as "1ʰ30ᵐ0ˢ (+30ᵐ0ˢ)".
+//
+// The difference is formatted with the same verb and precision and always
+// has a sign, so that a time earlier than the one before it, as with
+// unsorted input, shows a negative difference.  Times or differences that
+// cannot be formatted are output as by FormatTime.  If sym is nil, package
+// variable Default is used.
+func FormatSchedule(ts []unit.Time, verb rune, prec int, sym *Symbols) []string {
+	r := make([]string, len(ts))
+	var b []byte
+	for i, t := range ts {
+		b = (&Time{t, sym, nil}).AppendFormat(b[:0], verb, prec)
+		if i > 0 {
+			b = append(b, " ("...)
+			b = (&Time{t - ts[i-1], sym, nil}).AppendFormat(b, verb, prec, FlagPlus)
+			b = append(b, ')')
+		}
+		r[i] = string(b)
+	}
+	return r
+}
+
+// decFieldSymbols format declination catalog fields.
+var decFieldSymbols = &Symbols{
+	DMSUnits: UnitSymbols{" ", " ", ""},
+	DecSep:   ".",
+}
+
+// FormatDecField formats a declination as a fixed width catalog field,
+// for example "+41 16 09.1".
+//
+// The field always has a sign, two integer degree digits, two digit minutes,
+// and seconds with two integer digits and prec decimal places.  Segments are
+// separated by single spaces.  Small negative declinations keep the sign,
+// as in "-00 30 00.0".
+//
+// A declination outside the range -90° to +90° cannot be formatted.  The
+// result is then a field of asterisks and the error is a *FormatError
+// wrapping ErrOutOfRange.
+// Other value errors also give asterisks, as with the custom formatters.
+// An invalid precision gives an empty string and a *FormatError wrapping
+// ErrBadPrec.
+func FormatDecField(dec unit.Angle, prec int) (string, error) {
+	s := state{verb: secAppend, hrDeg: dec.Deg(), width: 2, widthOK: true,
+		prec: prec, precOK: true, sym: decFieldSymbols}
+	if err := s.checkFormat(); err != nil {
+		return "", err
+	}
+	f := &fmtState{wid: 2, widOK: true, prec: prec, flags: "+0"}
+	fa := &Angle{dec, decFieldSymbols, nil}
+	if math.Abs(dec.Deg()) > 90 {
+		fa.Angle = 0
+		fa.Format(f, 's')
+		if fa.Err != nil {
+			return string(f.buf), fa.Err
+		}
+		return strings.Repeat("*", runeWidth(string(f.buf))),
+			&FormatError{ErrOutOfRange, dec.Deg(), prec, f.wid}
+	}
+	fa.Format(f, 's')
+	return string(f.buf), fa.Err
+}
+
+// FormatToTolerance formats a to the resolution given by tol.
+//
+// Tol is a single decimal segment with a unit symbol of Default.DMSUnits,
+// such as "1″" for the nearest arc second or "0.1′" for the nearest tenth of
+// an arc minute.  The unit of tol selects the decimal segment of the result
+// and the decimal places of tol select the precision.  The result uses
+// the default symbols and unit convention, as with the %s, %m, or %h verbs.
+//
+// An unparsable tol gives a *ParseError.  Otherwise the error is any value
+// error, as with the custom formatters.
+func FormatToTolerance(a unit.Angle, tol string) (string, error) {
+	d := defaultSymbols()
+	verb, prec, err := d.parseTolerance(tol)
+	if err != nil {
+		return "", err
+	}
+	return FormatAngle(a, verb, prec, d)
+}
+
+// CanonicalizeAngle parses a loosely formatted angle and formats it again
+// with the verb, precision prec, and symbols sym.
+//
+// The input can separate degrees, minutes, and seconds with any of the common
+// glyphs for the units, such as °, d, ′, ', m, ″, ", or s, or with colons or
+// spaces.  It can have a leading sign or a hemisphere letter, and a decimal
+// point or comma in the last segment, as for ParseAngleFlexible.
+//
+// If sym is nil, package variable Default is used.  An input that cannot be
+// parsed gives a *ParseError, wrapping ErrSegmentRange for minutes or seconds
+// of 60 or more.  Otherwise the error is any value error as with the custom
+// formatters.
+func CanonicalizeAngle(s string, verb rune, prec int, sym *Symbols) (string, error) {
+	a, err := ParseAngleFlexible(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatAngle(a, verb, prec, sym)
+}
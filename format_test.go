@@ -0,0 +1,818 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleAngle_AppendRunes() {
+	a := sexa.FmtAngle(unit.NewAngle(' ', 12, 34, 45.6))
+	cells, err := a.AppendRunes(nil, 'c', 1)
+	fmt.Println(len(cells), err)
+	// the combining dot is a rune of its own
+	fmt.Printf("%q\n", cells[8:])
+	// Output:
+	// 11 <nil>
+	// ['″' '̣' '6']
+}
+
+func TestAppendRunes(t *testing.T) {
+	a := sexa.FmtAngle(unit.AngleFromDeg(1.5))
+	dst := []rune("Dec ")
+	for _, tc := range []struct {
+		verb rune
+		prec int
+		err  error
+	}{
+		{'s', 16, sexa.ErrBadPrec},
+		{'q', 0, sexa.ErrBadVerb},
+	} {
+		got, err := a.AppendRunes(dst, tc.verb, tc.prec)
+		if string(got) != "Dec " || !errors.Is(err, tc.err) ||
+			!errors.Is(a.Err, tc.err) {
+			t.Errorf("%c %d: got %q, %v want %v",
+				tc.verb, tc.prec, string(got), err, tc.err)
+		}
+	}
+}
+
+func ExampleAlignAngles() {
+	as := []unit.Angle{
+		unit.AngleFromDeg(123.456),
+		unit.AngleFromDeg(-1.5),
+		unit.AngleFromDeg(12),
+	}
+	for _, s := range sexa.AlignAngles(as, 'i', 2, nil) {
+		fmt.Printf("|%s|\n", s)
+	}
+	// Output:
+	// |123°̣46|
+	// | -1°̣50|
+	// | 12°̣00|
+}
+
+func TestAlignAngles(t *testing.T) {
+	as := []unit.Angle{
+		unit.NewAngle('-', 0, 0, 2.5),
+		unit.NewAngle(' ', 1, 2, 3),
+		unit.Angle(math.NaN()),
+	}
+	got := sexa.AlignAngles(as, 's', 1, sexa.ASCII)
+	want := []string{"   -2.5s", "1d2m3.0s", "    ****"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q", got)
+	}
+	if got = sexa.AlignAngles(nil, 's', 0, nil); len(got) != 0 {
+		t.Errorf("got %q", got)
+	}
+}
+
+func ExampleFormatRange() {
+	lo := unit.NewAngle(' ', 12, 34, 45)
+	hi := unit.NewAngle(' ', 12, 34, 47)
+	r, err := sexa.FormatRange(lo, hi, 's', 0, nil)
+	fmt.Println(r, err)
+	// sides are padded to the same width
+	r, err = sexa.FormatRange(unit.NewAngle('-', 0, 0, 2.5),
+		unit.NewAngle(' ', 0, 1, 2.5), 's', 1, nil)
+	fmt.Printf("%q %v\n", r, err)
+	// Output:
+	// 12°34′45″ – 12°34′47″ <nil>
+	// " -2.5″ – 1′2.5″" <nil>
+}
+
+func TestFormatRange(t *testing.T) {
+	sym := &sexa.Symbols{DMSUnits: sexa.UnitSymbols{"d", "m", "s"},
+		RangeSep: ".."}
+	got, err := sexa.FormatRange(unit.AngleFromDeg(1), unit.AngleFromDeg(200),
+		's', 0, sym)
+	if want := "  1d0m0s..200d0m0s"; got != want || err != nil {
+		t.Fatal(got, err, "want", want)
+	}
+	got, err = sexa.FormatRange(unit.AngleFromDeg(1),
+		unit.Angle(math.Inf(1)), 's', 0, sym)
+	if want := "1d0m0s..    **"; got != want || !errors.Is(err, sexa.ErrPosInf) {
+		t.Fatal(got, err, "want", want)
+	}
+	got, err = sexa.FormatRange(unit.AngleFromDeg(1), unit.AngleFromDeg(2),
+		's', 16, sym)
+	if got != "" || !errors.Is(err, sexa.ErrBadPrec) {
+		t.Fatal(got, err)
+	}
+}
+
+func ExampleFormatSchedule() {
+	ts := []unit.Time{
+		unit.NewTime(' ', 1, 0, 0),
+		unit.NewTime(' ', 1, 30, 0),
+		unit.NewTime(' ', 2, 45, 30),
+	}
+	for _, s := range sexa.FormatSchedule(ts, 's', 0, nil) {
+		fmt.Println(s)
+	}
+	// Output:
+	// 1ʰ0ᵐ0ˢ
+	// 1ʰ30ᵐ0ˢ (+30ᵐ0ˢ)
+	// 2ʰ45ᵐ30ˢ (+1ʰ15ᵐ30ˢ)
+}
+
+func TestFormatSchedule(t *testing.T) {
+	ts := []unit.Time{
+		unit.TimeFromHour(2),
+		unit.TimeFromHour(1.5), // out of order
+		unit.TimeFromHour(1.5),
+		unit.Time(math.Inf(1)),
+	}
+	got := sexa.FormatSchedule(ts, 'm', 1, sexa.ASCII)
+	want := []string{
+		"2h0.0m",
+		"1h30.0m (-30.0m)",
+		"1h30.0m (+0.0m)",
+		"**** (*****)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q", got)
+	}
+	if got = sexa.FormatSchedule(nil, 's', 0, nil); len(got) != 0 {
+		t.Errorf("got %q", got)
+	}
+}
+
+func ExampleFormatDecField() {
+	for _, d := range []unit.Angle{
+		unit.NewAngle(' ', 41, 16, 9.12),
+		unit.NewAngle('-', 0, 30, 0),
+		unit.NewAngle('-', 90, 0, 0),
+		unit.NewAngle(' ', 90, 0, 0.1),
+	} {
+		f, err := sexa.FormatDecField(d, 1)
+		fmt.Printf("%q %v\n", f, err)
+	}
+	// Output:
+	// "+41 16 09.1" <nil>
+	// "-00 30 00.0" <nil>
+	// "-90 00 00.0" <nil>
+	// "***********" Formatting 90.00002777777779 at precision 1 in width 2: Value out of range
+}
+
+func TestFormatDecField(t *testing.T) {
+	f, err := sexa.FormatDecField(unit.AngleFromDeg(-100), 0)
+	var fe *sexa.FormatError
+	if f != "*********" || !errors.As(err, &fe) ||
+		!errors.Is(err, sexa.ErrOutOfRange) || fe.Value != -100 ||
+		fe.Prec != 0 || fe.Width != 2 {
+		t.Errorf("got %q, %#v", f, err)
+	}
+	f, err = sexa.FormatDecField(unit.AngleFromDeg(45), 16)
+	if f != "" || !errors.Is(err, sexa.ErrBadPrec) {
+		t.Errorf("got %q, %v", f, err)
+	}
+}
+
+func ExampleFormatToTolerance() {
+	a := unit.NewAngle(' ', 12, 34, 45.678)
+	for _, tol := range []string{"1″", "0.01″", "0.1′", "5°", "1x"} {
+		f, err := sexa.FormatToTolerance(a, tol)
+		fmt.Println(f, err)
+	}
+	// Output:
+	// 12°34′46″ <nil>
+	// 12°34′45.68″ <nil>
+	// 12°34.8′ <nil>
+	// 13° <nil>
+	//  Parsing "1x": Invalid syntax
+}
+
+func ExampleAppendUnitAngle() {
+	var b []byte
+	for _, a := range []unit.Angle{
+		unit.NewAngle(' ', 12, 34, 45.6),
+		unit.NewAngle('-', 1, 2, 3.4),
+	} {
+		b, _ = sexa.AppendUnitAngle(b, a, 's', 1, nil)
+		b = append(b, '\n')
+	}
+	fmt.Print(string(b))
+	// Output:
+	// 12°34′45.6″
+	// -1°2′3.4″
+}
+
+func TestAppendUnit(t *testing.T) {
+	b, err := sexa.AppendUnitHourAngle([]byte("HA "),
+		unit.NewHourAngle('-', 1, 2, 3), 's', 0, nil)
+	if string(b) != "HA -1ʰ2ᵐ3ˢ" || err != nil {
+		t.Error(string(b), err)
+	}
+	b, err = sexa.AppendUnitRA(nil, unit.RAFromHour(25), 'h', 1, nil)
+	if string(b) != "1.0ʰ" || err != nil {
+		t.Error(string(b), err)
+	}
+	b, err = sexa.AppendUnitTime(nil, unit.Time(math.NaN()), 's', 0, nil)
+	if string(b) != "**" || !errors.Is(err, sexa.ErrNaN) {
+		t.Error(string(b), err)
+	}
+	b, err = sexa.AppendUnitAngle([]byte("Dec "), unit.AngleFromDeg(1), 's',
+		16, nil)
+	if string(b) != "Dec " || !errors.Is(err, sexa.ErrBadPrec) {
+		t.Error(string(b), err)
+	}
+}
+
+func TestAppendUnitAllocs(t *testing.T) {
+	b := make([]byte, 0, 64)
+	for _, f := range []func(){
+		func() { b, _ = sexa.AppendUnitAngle(b[:0], unit.AngleFromDeg(-12.5), 'c', 2, nil) },
+		func() { b, _ = sexa.AppendUnitHourAngle(b[:0], unit.HourAngleFromHour(1.5), 'm', 1, nil) },
+		func() { b, _ = sexa.AppendUnitRA(b[:0], unit.RAFromHour(12.5), 's', 3, nil) },
+		func() { b, _ = sexa.AppendUnitTime(b[:0], unit.TimeFromHour(2.5), 'h', 0, nil) },
+	} {
+		if n := testing.AllocsPerRun(100, f); n != 0 {
+			t.Errorf("%s: %g allocs", b, n)
+		}
+	}
+}
+
+func BenchmarkAppendUnitAngle(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	a := unit.NewAngle('-', 12, 34, 45.6789)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = sexa.AppendUnitAngle(buf[:0], a, 's', 3, nil)
+	}
+}
+
+func ExampleCanonicalizeAngle() {
+	for _, s := range []string{
+		"12 34 45.6",
+		"12:34:45.6",
+		"12d34m45.6s",
+		`12°34'45.6"`,
+		"-12º 34’ 45,6”",
+		"12°34′45″.6",
+	} {
+		c, err := sexa.CanonicalizeAngle(s, 's', 1, nil)
+		fmt.Println(c, err)
+	}
+	// Output:
+	// 12°34′45.6″ <nil>
+	// 12°34′45.6″ <nil>
+	// 12°34′45.6″ <nil>
+	// 12°34′45.6″ <nil>
+	// -12°34′45.6″ <nil>
+	// 12°34′45.6″ <nil>
+}
+
+func TestCanonicalizeAngle(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{"12", "12°0′0″"},
+		{"12.5", "12°30′0″"},
+		{"  +12 30  ", "12°30′0″"},
+		{"12 30.5", "12°30′30″"},
+		{"30'", "30′0″"},
+		{`45"`, "45″"},
+		{"45''", "45″"},
+		{"1°2′3″", "1°2′3″"},
+		{"1°2′3″̣5", "1°2′4″"},
+		{"1d 2m 3s", "1°2′3″"},
+		{"−1°2′3″", "-1°2′3″"},
+		{"1˚2ʹ3ʺ", "1°2′3″"},
+		{"1D2M3S", "1°2′3″"},
+		{"1°3″", "1°0′3″"},
+		{`12°34'45"N`, "12°34′45″"},
+		{"W 1 2 3", "-1°2′3″"},
+	} {
+		got, err := sexa.CanonicalizeAngle(tc.in, 's', 0, nil)
+		if err != nil || got != tc.want {
+			t.Errorf("%q: got %q, %v want %q", tc.in, got, err, tc.want)
+		}
+	}
+	for _, in := range []string{
+		"", "-", "abc", "12x", "1.5 30", "1 2 3 4", "3″2′", "1..2",
+		"1°2′3″.4.5", "12°°",
+	} {
+		got, err := sexa.CanonicalizeAngle(in, 's', 0, nil)
+		var pe *sexa.ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, sexa.ErrSyntax) {
+			t.Errorf("%q: got %q, %v, want ParseError", in, got, err)
+		}
+	}
+	for _, in := range []string{"12:34:75", "1 60", "12°60′"} {
+		got, err := sexa.CanonicalizeAngle(in, 's', 0, nil)
+		var pe *sexa.ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, sexa.ErrSegmentRange) {
+			t.Errorf("%q: got %q, %v, want ErrSegmentRange", in, got, err)
+		}
+	}
+	// value errors are distinct from parse errors
+	got, err := sexa.CanonicalizeAngle("400", '2', 0, nil)
+	if got != "" || !errors.Is(err, sexa.ErrBadVerb) {
+		t.Fatal(got, err)
+	}
+	got, err = sexa.CanonicalizeAngle("9.1", 'h', 15, nil)
+	var pe *sexa.ParseError
+	if errors.As(err, &pe) || !errors.Is(err, sexa.ErrLossOfPrecision) {
+		t.Fatal(got, err)
+	}
+}
+
+func ExampleAngle_AppendFormat() {
+	a := sexa.FmtAngle(unit.NewAngle('-', 0, 34, 45.6))
+	b := a.AppendFormat([]byte("Dec "), 's', 1)
+	b = append(b, ", "...)
+	b = a.AppendFormat(b, 's', 1, sexa.FlagSharp, sexa.FlagZero)
+	fmt.Println(string(b))
+	// Output:
+	// Dec -34′45.6″, -0°34′45.6″
+}
+
+// AppendFormat matches Format, including overflow.
+func TestAppendFormat(t *testing.T) {
+	for _, x := range []float64{0, 1.5, -12.3456789, 359.99999, math.Inf(-1)} {
+		for _, f := range []string{"%s", "%.2c", "%+.1d", "% .3m", "%#h",
+			"%.1i", "%#0o", "%g"} {
+			prec := 0
+			var flags []sexa.Flag
+			for _, c := range f[1 : len(f)-1] {
+				switch c {
+				case '.':
+				case '+', ' ', '#', '0':
+					flags = append(flags, sexa.Flag(c))
+				default:
+					prec = int(c - '0')
+				}
+			}
+			verb := rune(f[len(f)-1])
+			a := sexa.FmtAngle(unit.AngleFromDeg(x))
+			want := fmt.Sprintf(f, a)
+			werr := a.Err
+			got := string(a.AppendFormat(nil, verb, prec, flags...))
+			if got != want || fmt.Sprint(a.Err) != fmt.Sprint(werr) {
+				t.Errorf("%s %v: got %s, %v want %s, %v", f, x, got, a.Err, want, werr)
+			}
+			h := sexa.FmtHourAngle(unit.HourAngleFromHour(x))
+			ra := sexa.FmtRA(unit.RAFromHour(x))
+			tm := sexa.FmtTime(unit.TimeFromHour(x))
+			if got, want := string(h.AppendFormat(nil, verb, prec, flags...)),
+				fmt.Sprintf(f, h); got != want {
+				t.Errorf("%s %v: got %s want %s", f, x, got, want)
+			}
+			if got, want := string(ra.AppendFormat(nil, verb, prec, flags...)),
+				fmt.Sprintf(f, ra); got != want {
+				t.Errorf("%s %v: got %s want %s", f, x, got, want)
+			}
+			if got, want := string(tm.AppendFormat(nil, verb, prec, flags...)),
+				fmt.Sprintf(f, tm); got != want {
+				t.Errorf("%s %v: got %s want %s", f, x, got, want)
+			}
+		}
+	}
+	a := sexa.FmtAngle(1)
+	if got := string(a.AppendFormat(nil, 's', -1)); got != "%!(BADPREC -1)" {
+		t.Error(got)
+	}
+}
+
+func TestAppendFormatAllocs(t *testing.T) {
+	b := make([]byte, 0, 64)
+	a := sexa.FmtAngle(unit.NewAngle('-', 12, 34, 45.6789))
+	ha := sexa.FmtHourAngle(unit.HourAngleFromHour(-1.5))
+	ra := sexa.FmtRA(unit.RAFromHour(12.5))
+	tm := sexa.FmtTime(unit.TimeFromHour(2.5))
+	for _, f := range []func(){
+		func() { b = a.AppendFormat(b[:0], 's', 3) },
+		func() { b = a.AppendFormat(b[:0], 'c', 1, sexa.FlagPlus, sexa.FlagSharp) },
+		func() { b = ha.AppendFormat(b[:0], 'm', 2) },
+		func() { b = ra.AppendFormat(b[:0], 'h', 1) },
+		func() { b = tm.AppendFormat(b[:0], 's', 0, sexa.FlagZero) },
+	} {
+		if n := testing.AllocsPerRun(100, f); n != 0 {
+			t.Errorf("%s: %g allocs", b, n)
+		}
+	}
+}
+
+func BenchmarkAppendFormat(b *testing.B) {
+	a := sexa.FmtAngle(unit.NewAngle('-', 12, 34, 45.6789))
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = a.AppendFormat(buf[:0], 's', 3)
+	}
+}
+
+func BenchmarkSprintf(b *testing.B) {
+	a := sexa.FmtAngle(unit.NewAngle('-', 12, 34, 45.6789))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fmt.Sprintf("%.3s", a)
+	}
+}
+
+func BenchmarkFormatAngle(b *testing.B) {
+	a := unit.NewAngle('-', 12, 34, 45.6789)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = sexa.FormatAngle(a, 's', 3, nil)
+	}
+}
+
+func ExampleRA_WriteFormat() {
+	ra := sexa.FmtRA(unit.NewRA(12, 34, 45.6))
+	n, err := ra.WriteFormat(os.Stdout, 's', 1)
+	fmt.Println()
+	fmt.Println(n, err)
+	// Output:
+	// 12ʰ34ᵐ45.6ˢ
+	// 15 <nil>
+}
+
+type failWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failWriter) Write(b []byte) (int, error) { return 0, errWrite }
+
+func TestWriteFormat(t *testing.T) {
+	var b strings.Builder
+	a := sexa.FmtAngle(unit.Angle(math.Inf(1)))
+	n, err := a.WriteFormat(&b, 's', 0)
+	if b.String() != "**" || n != 2 || err != nil || !errors.Is(a.Err, sexa.ErrPosInf) {
+		t.Fatal(b.String(), n, err, a.Err)
+	}
+	tm := sexa.FmtTime(unit.Time(3723))
+	if _, err = tm.WriteFormat(failWriter{}, 's', 0); err != errWrite {
+		t.Fatal(err)
+	}
+	h := sexa.FmtHourAngle(unit.HourAngle(1))
+	b.Reset()
+	if _, err = h.WriteFormat(&b, 'x', 0); b.String() != "%!x(BADVERB)" {
+		t.Fatal(b.String(), err)
+	}
+}
+
+func ExampleAngle_AppendString() {
+	var sb strings.Builder
+	for i, d := range []float64{12.5, -3.25} {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sexa.FmtAngle(unit.AngleFromDeg(d)).AppendString(&sb, 's', 1)
+	}
+	fmt.Println(sb.String())
+	// Output:
+	// 12°30′0.0″, -3°15′0.0″
+}
+
+func TestAppendString(t *testing.T) {
+	for _, tc := range []struct {
+		a    unit.Angle
+		verb rune
+		prec int
+	}{
+		{unit.NewAngle('-', 1, 2, 3.456), 's', 2},
+		{unit.NewAngle(' ', 1, 2, 3.456), 'c', 1},
+		{unit.NewAngle(' ', 1, 2, 3.456), 'h', 4},
+		{unit.AngleFromSec(5e12 + 1./1024), 's', 3},
+		{unit.Angle(math.NaN()), 'm', 1},
+	} {
+		a := sexa.FmtAngle(tc.a)
+		want := fmt.Sprintf("%.*"+string(tc.verb), tc.prec, a)
+		wantErr := a.Err
+		var sb strings.Builder
+		sb.WriteString("|")
+		a.AppendString(&sb, tc.verb, tc.prec)
+		if got := sb.String(); got != "|"+want || a.Err != wantErr &&
+			a.Err.Error() != wantErr.Error() {
+			t.Errorf("%c %d: got %q, %v want %q, %v",
+				tc.verb, tc.prec, got, a.Err, want, wantErr)
+		}
+	}
+	// invalid arguments are errors rather than output
+	a := sexa.FmtAngle(unit.AngleFromDeg(1.5))
+	for _, tc := range []struct {
+		verb rune
+		prec int
+		err  error
+	}{
+		{'s', 16, sexa.ErrBadPrec},
+		{'x', 0, sexa.ErrBadVerb},
+	} {
+		var sb strings.Builder
+		a.AppendString(&sb, tc.verb, tc.prec)
+		if sb.Len() != 0 || !errors.Is(a.Err, tc.err) {
+			t.Errorf("%c %d: got %q, %v want %v",
+				tc.verb, tc.prec, sb.String(), a.Err, tc.err)
+		}
+	}
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	a := sexa.FmtAngle(unit.NewAngle('-', 12, 34, 45.6789))
+	var sb strings.Builder
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		a.AppendString(&sb, 's', 3)
+	}
+}
+
+func ExampleAngle_Sexagesimal() {
+	a := sexa.FmtAngle(unit.NewAngle(' ', 1, 2, 3.45))
+	for prec := 0; prec < 3; prec++ {
+		s, err := a.Sexagesimal(prec, sexa.FormatFlags{ForceSign: true})
+		fmt.Println(s, err)
+	}
+	s, err := a.Sexagesimal(1, sexa.FormatFlags{
+		ZeroPad:     true,
+		Width:       3,
+		DecimalUnit: sexa.UnitInserted,
+	})
+	fmt.Println(s, err)
+	// Output:
+	// +1°2′3″ <nil>
+	// +1°2′3.5″ <nil>
+	// +1°2′3.45″ <nil>
+	//  001°02′03″.5 <nil>
+}
+
+// Sexagesimal matches the equivalent format specifier.
+func TestSexagesimal(t *testing.T) {
+	verbs := map[sexa.DecimalUnit]string{
+		sexa.UnitFollowing: "s",
+		sexa.UnitCombined:  "c",
+		sexa.UnitInserted:  "d",
+	}
+	for _, x := range []float64{0, 1.5, -12.3456789, 359.99999, 1000,
+		math.NaN()} {
+		a := sexa.FmtAngle(unit.AngleFromDeg(x))
+		for _, du := range []sexa.DecimalUnit{sexa.UnitFollowing,
+			sexa.UnitCombined, sexa.UnitInserted} {
+			for bits := 0; bits < 8; bits++ {
+				for _, wid := range []int{0, 3} {
+					ff := sexa.FormatFlags{
+						ForceSign:   bits&1 != 0,
+						AllSegments: bits&2 != 0,
+						ZeroPad:     bits&4 != 0,
+						Width:       wid,
+						DecimalUnit: du,
+					}
+					f := "%"
+					if ff.ForceSign {
+						f += "+"
+					}
+					if ff.AllSegments {
+						f += "#"
+					}
+					if ff.ZeroPad {
+						f += "0"
+					}
+					if wid > 0 {
+						f += fmt.Sprint(wid)
+					}
+					f += ".2" + verbs[du]
+					want := fmt.Sprintf(f, a)
+					wantErr := a.Err
+					got, err := a.Sexagesimal(2, ff)
+					if got != want || fmt.Sprint(err) != fmt.Sprint(wantErr) {
+						t.Errorf("%s %v: got %q, %v want %q, %v",
+							f, x, got, err, want, wantErr)
+					}
+				}
+			}
+		}
+	}
+	// invalid arguments are errors rather than output
+	a := sexa.FmtAngle(unit.AngleFromDeg(1.5))
+	for _, prec := range []int{-1, 16} {
+		if got, err := a.Sexagesimal(prec, sexa.FormatFlags{}); got != "" ||
+			!errors.Is(err, sexa.ErrBadPrec) {
+			t.Errorf("prec %d: got %q, %v", prec, got, err)
+		}
+	}
+	got, err := a.Sexagesimal(0, sexa.FormatFlags{Width: -1})
+	if got != "" || !errors.Is(err, sexa.ErrBadWidth) {
+		t.Errorf("width -1: got %q, %v", got, err)
+	}
+	a.Sym = &sexa.Symbols{HighPrecision: true}
+	if _, err = a.Sexagesimal(16, sexa.FormatFlags{}); errors.Is(err, sexa.ErrBadPrec) {
+		t.Error(err)
+	}
+}
+
+func ExampleAngle_totalSec() {
+	a := sexa.FmtAngle(unit.NewAngle(' ', 12, 34, 56.4))
+	fmt.Printf("%.1S\n", a)
+	fmt.Printf("%.1S\n", sexa.FmtTime(unit.NewTime('-', 1, 0, 1.25)))
+	// Output:
+	// 45296.4″
+	// -3601.3ˢ
+}
+
+func ExampleAngle_totalMin() {
+	a := sexa.FmtAngle(unit.NewAngle(' ', 45, 18, 45.6))
+	fmt.Printf("%.2M  %.2m\n", a, a)
+	// Output:
+	// 2718.76′  45°18.76′
+}
+
+func ExampleFormatAngle() {
+	s, err := sexa.FormatAngle(unit.NewAngle(' ', 12, 34, 45.6), 's', 1, nil)
+	fmt.Println(s, err)
+	s, err = sexa.FormatRA(unit.RAFromHour(-1), 'm', 0, nil)
+	fmt.Println(s, err)
+	s, err = sexa.FormatTime(unit.Time(math.Inf(1)), 's', 0, nil)
+	fmt.Println(s, err)
+	// Output:
+	// 12°34′45.6″ <nil>
+	// 23ʰ0ᵐ <nil>
+	// ** +Inf
+}
+
+// FormatAngle matches the custom formatter.
+func TestFormatAngleSprintf(t *testing.T) {
+	for _, a := range []unit.Angle{
+		0,
+		unit.NewAngle('-', 12, 34, 45.6789),
+		unit.NewAngle(' ', 359, 59, 59.99999),
+		unit.AngleFromDeg(-1e-9),
+		unit.AngleFromDeg(1e6),
+		unit.Angle(math.NaN()),
+	} {
+		for prec := 0; prec <= 15; prec++ {
+			want := fmt.Sprintf("%.*s", prec, sexa.FmtAngle(a))
+			if got, _ := sexa.FormatAngle(a, 's', prec, nil); got != want {
+				t.Errorf("%g %d: got %s want %s", a.Deg(), prec, got, want)
+			}
+		}
+	}
+}
+
+// The functional API returns an error for an invalid verb or precision,
+// rather than the output of the custom formatter.
+func TestFormatBadFormat(t *testing.T) {
+	for _, tc := range []struct {
+		verb rune
+		prec int
+		err  error
+	}{
+		{'s', 16, sexa.ErrBadPrec},
+		{'s', -1, sexa.ErrBadPrec},
+		{'q', 0, sexa.ErrBadVerb},
+	} {
+		for _, f := range []func() (string, error){
+			func() (string, error) {
+				return sexa.FormatAngle(unit.AngleFromDeg(1.5), tc.verb, tc.prec, nil)
+			},
+			func() (string, error) {
+				return sexa.FormatHourAngle(unit.HourAngleFromHour(1.5), tc.verb, tc.prec, nil)
+			},
+			func() (string, error) {
+				return sexa.FormatRA(unit.RAFromHour(1.5), tc.verb, tc.prec, nil)
+			},
+			func() (string, error) {
+				return sexa.FormatTime(unit.TimeFromHour(1.5), tc.verb, tc.prec, nil)
+			},
+		} {
+			got, err := f()
+			var fe *sexa.FormatError
+			if got != "" || !errors.Is(err, tc.err) || !errors.As(err, &fe) {
+				t.Errorf("%c %d: got %q, %v want %v",
+					tc.verb, tc.prec, got, err, tc.err)
+			}
+		}
+	}
+	// the precision does not apply to %k
+	if got, err := sexa.FormatAngle(unit.AngleFromDeg(1.5), 'k', 16, nil); got != "2" || err != nil {
+		t.Errorf("k 16: got %q, %v", got, err)
+	}
+}
+
+// The functional API is safe for concurrent use.
+func TestFormatConcurrent(t *testing.T) {
+	done := make(chan bool)
+	for g := 0; g < 8; g++ {
+		go func(g int) {
+			h := unit.HourAngleFromHour(float64(g))
+			want := fmt.Sprintf("%.2s", sexa.FmtHourAngle(h))
+			for i := 0; i < 100; i++ {
+				got, err := sexa.FormatHourAngle(h, 's', 2, nil)
+				if got != want || err != nil {
+					t.Errorf("got %s, %v want %s", got, err, want)
+				}
+			}
+			done <- true
+		}(g)
+	}
+	for g := 0; g < 8; g++ {
+		<-done
+	}
+}
+
+func ExampleAngle_StringPrec() {
+	a := sexa.FmtAngle(unit.NewAngle(' ', 12, 34, 45.678))
+	fmt.Println(a.StringPrec(2))
+	a.Sym = sexa.ASCII
+	fmt.Println(a.StringPrec(1))
+	lat := sexa.FmtLatitude(unit.NewAngle('-', 12, 34, 45.678))
+	fmt.Println(lat.StringPrec(1))
+	// Output:
+	// 12°34′45.68″
+	// 12d34m45.7s
+	// 12°34′45.7″S
+}
+
+func TestStringPrec(t *testing.T) {
+	for _, v := range []interface {
+		sexa.SexaFormatter
+		StringPrec(int) string
+	}{
+		sexa.FmtAngle(unit.NewAngle('-', 12, 34, 45.678)),
+		sexa.FmtHourAngle(unit.NewHourAngle('-', 1, 34, 45.678)),
+		sexa.FmtRA(unit.NewRA(1, 34, 45.678)),
+		sexa.FmtTime(unit.NewTime(' ', 1, 34, 45.678)),
+		sexa.FmtLatitude(unit.NewAngle('-', 12, 34, 45.678)),
+		sexa.FmtLongitude(unit.NewAngle('-', 12, 34, 45.678)),
+		sexa.FmtDec(unit.NewAngle('-', 12, 34, 45.678)),
+		sexa.FmtEqCoord(unit.NewRA(1, 34, 45.678), unit.NewAngle('-', 12, 34, 45.678)),
+	} {
+		for prec := 0; prec < 4; prec++ {
+			if got, want := v.StringPrec(prec), fmt.Sprintf("%.*s", prec, v); got != want {
+				t.Errorf("got %s want %s", got, want)
+			}
+		}
+		if v.StringPrec(0) != v.String() {
+			t.Errorf("%s at 0, String %s", v.StringPrec(0), v.String())
+		}
+		// bad precision is reported as by the other formatting methods,
+		// once for each value of a coordinate pair
+		got := v.StringPrec(-1)
+		if strings.TrimSpace(strings.ReplaceAll(got, "%!(BADPREC -1)", "")) != "" {
+			t.Errorf("got %s", got)
+		}
+	}
+	a := sexa.FmtAngle(unit.Angle(math.NaN()))
+	if got := a.StringPrec(2); !errors.Is(a.Err, sexa.ErrNaN) {
+		t.Errorf("got %s, %v", got, a.Err)
+	}
+}
+
+func ExampleFuncMap() {
+	tmpl := template.Must(template.New("").Funcs(sexa.FuncMap(nil)).Parse(
+		"{{ra .RA}} {{angle .Dec 1}} {{time .T 2}}\n"))
+	tmpl.Execute(os.Stdout, struct {
+		RA  unit.RA
+		Dec unit.Angle
+		T   unit.Time
+	}{unit.NewRA(12, 34, 45.6), unit.NewAngle('-', 1, 2, 3.45), unit.Time(1.5)})
+	// Output:
+	// 12ʰ34ᵐ46ˢ -1°2′3.5″ 1.50ˢ
+}
+
+func TestFuncMap(t *testing.T) {
+	fm := sexa.FuncMap(sexa.ASCII)
+	for _, tc := range []struct {
+		text string
+		data interface{}
+		want string
+	}{
+		{"{{angle .}}", unit.AngleFromDeg(1.5), "1d30m0s"},
+		{"{{hourAngle . 1}}", unit.HourAngleFromHour(-1.5), "-1h30m0.0s"},
+		{"{{ra .}}", unit.RAFromHour(25), "1h0m0s"},
+		{"{{time . 0}}", unit.Time(61), "1m1s"},
+		// value errors are output, not returned
+		{"{{angle .}}", unit.Angle(math.NaN()), "**"},
+	} {
+		var b strings.Builder
+		err := template.Must(template.New("").Funcs(fm).Parse(tc.text)).Execute(&b, tc.data)
+		if err != nil || b.String() != tc.want {
+			t.Errorf("%s: got %q, %v want %q", tc.text, b.String(), err, tc.want)
+		}
+	}
+	// an invalid precision is returned
+	err := template.Must(template.New("").Funcs(fm).Parse("{{angle . 16}}")).
+		Execute(io.Discard, unit.AngleFromDeg(1))
+	if !errors.Is(err, sexa.ErrBadPrec) {
+		t.Errorf("{{angle . 16}}: got %v", err)
+	}
+	// html/template takes the same functions
+	var b strings.Builder
+	err = htmltemplate.Must(htmltemplate.New("").Funcs(htmltemplate.FuncMap(fm)).
+		Parse("{{angle .}}")).Execute(&b, unit.AngleFromDeg(1))
+	if err != nil || b.String() != "1d0m0s" {
+		t.Errorf("got %q, %v", b.String(), err)
+	}
+}
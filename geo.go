@@ -0,0 +1,181 @@
+// License: MIT
+
+package sexa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/soniakeys/unit"
+)
+
+// Latitude represents a formattable geographic latitude.
+//
+// Latitude formats as Angle does except that the sign is indicated by
+// a hemisphere indicator following the value rather than by a leading sign.
+// The indicators are those of Symbols.NorthSouth.  The sign formatting flags
+// '+' and ' ' are ignored.
+//
+// A latitude beyond ±90° cannot be formatted.  The output then contains
+// asterisks and Err is a *FormatError wrapping ErrOutOfRange, as tested with
+// errors.Is(lat.Err, ErrOutOfRange).
+type Latitude struct {
+	unit.Angle
+	Sym *Symbols
+	Err error // set each time the value is formatted.
+}
+
+// FmtLatitude constructs a formattable Latitude containing the value lat.
+func FmtLatitude(lat unit.Angle) *Latitude { return &Latitude{Angle: lat} }
+
+// FmtLatitude constructs a formattable Latitude containing the value lat.
+func (sym *Symbols) FmtLatitude(lat unit.Angle) *Latitude {
+	return &Latitude{lat, sym, nil}
+}
+
+// northSouth returns sym.NorthSouth, or the defaults "N" and "S".
+func (sym *Symbols) northSouth() [2]string {
+	if sym.NorthSouth[0] == "" {
+		return [2]string{"N", "S"}
+	}
+	return sym.NorthSouth
+}
+
+// Format implements fmt.Formatter
+func (lat *Latitude) Format(f fmt.State, c rune) {
+	s := state{
+		verb:   c,
+		hrDeg:  lat.Deg(),
+		caller: fsAngle,
+		sym:    lat.Sym,
+		limit:  90,
+	}
+	if s.sym == nil {
+		s.sym = defaultSymbols()
+	}
+	s.hemi = s.sym.northSouth()
+	s.fromFmt(f)
+	lat.Err = s.writeFormatted()
+}
+
+// String implements fmt.Stringer
+func (lat *Latitude) String() string { return fmt.Sprintf("%s", lat) }
+
+// StringPrec formats lat as String does, with precision prec.
+func (lat *Latitude) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	lat.Format(f, 's')
+	return string(f.buf)
+}
+
+// Longitude represents a formattable geographic longitude, positive east.
+//
+// Longitude formats as Latitude does, with the hemisphere indicators of
+// Symbols.EastWest.  The value is first wrapped to the range (-180°, 180°],
+// so for example 190° formats as 170°W.
+type Longitude struct {
+	unit.Angle
+	Sym *Symbols
+	Err error // set each time the value is formatted.
+}
+
+// FmtLongitude constructs a formattable Longitude containing the value lon.
+func FmtLongitude(lon unit.Angle) *Longitude { return &Longitude{Angle: lon} }
+
+// FmtLongitude constructs a formattable Longitude containing the value lon.
+func (sym *Symbols) FmtLongitude(lon unit.Angle) *Longitude {
+	return &Longitude{lon, sym, nil}
+}
+
+// eastWest returns sym.EastWest, or the defaults "E" and "W".
+func (sym *Symbols) eastWest() [2]string {
+	if sym.EastWest[0] == "" {
+		return [2]string{"E", "W"}
+	}
+	return sym.EastWest
+}
+
+// Format implements fmt.Formatter
+func (lon *Longitude) Format(f fmt.State, c rune) {
+	d := 180 - unit.PMod(180-lon.Deg(), 360) // wrap to (-180, 180]
+	s := state{
+		verb:   c,
+		hrDeg:  d,
+		caller: fsAngle,
+		sym:    lon.Sym,
+	}
+	if s.sym == nil {
+		s.sym = defaultSymbols()
+	}
+	s.hemi = s.sym.eastWest()
+	s.fromFmt(f)
+	lon.Err = s.writeFormatted()
+}
+
+// String implements fmt.Stringer
+func (lon *Longitude) String() string { return fmt.Sprintf("%s", lon) }
+
+// StringPrec formats lon as String does, with precision prec.
+func (lon *Longitude) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	lon.Format(f, 's')
+	return string(f.buf)
+}
+
+// ParsePackedLat parses a latitude packed as the digits DDMMSS, optionally
+// followed by a decimal point and decimal seconds, and then a hemisphere
+// letter N or S, as in the "404451N" of legacy GPS and ephemeris files.
+//
+// Errors are a *ParseError, wrapping ErrSyntax for malformed input,
+// ErrUnitSymbol for a missing or invalid hemisphere letter, or
+// ErrSegmentRange for minutes or seconds of 60 or more or a latitude beyond
+// 90°.
+func ParsePackedLat(s string) (unit.Angle, error) {
+	return parsePacked(s, 2, 90, "NS")
+}
+
+// ParsePackedLon parses a longitude packed as the digits DDDMMSS, optionally
+// followed by a decimal point and decimal seconds, and then a hemisphere
+// letter E or W, as in "0735959W".  The result is positive east.  Errors are
+// as for ParsePackedLat, with longitude limited to 180°.
+func ParsePackedLon(s string) (unit.Angle, error) {
+	return parsePacked(s, 3, 180, "EW")
+}
+
+// parsePacked parses s, packed with nDeg digits of degrees, and one of the
+// letters of hemi for positive or negative values.  The magnitude is limited
+// to max degrees.
+func parsePacked(s string, nDeg int, max int, hemi string) (unit.Angle, error) {
+	nInt := nDeg + 4
+	if len(s) < nInt+1 {
+		return 0, &ParseError{s, ErrSyntax}
+	}
+	h := strings.IndexByte(hemi, s[len(s)-1])
+	if h < 0 {
+		return 0, &ParseError{s, ErrUnitSymbol}
+	}
+	digits, frac := s[:nInt], s[nInt:len(s)-1]
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, &ParseError{s, ErrSyntax}
+		}
+	}
+	if frac > "" {
+		if len(frac) < 2 || frac[0] != '.' ||
+			strings.Trim(frac[1:], "0123456789") > "" {
+			return 0, &ParseError{s, ErrSyntax}
+		}
+	}
+	d, _ := strconv.Atoi(digits[:nDeg])
+	m, _ := strconv.Atoi(digits[nDeg : nDeg+2])
+	sec, _ := strconv.ParseFloat(digits[nDeg+2:]+frac, 64)
+	if m >= 60 || sec >= 60 || d > max || d == max && (m > 0 || sec > 0) {
+		return 0, &ParseError{s, ErrSegmentRange}
+	}
+	sign := byte(' ')
+	if h == 1 {
+		sign = '-'
+	}
+	return unit.NewAngle(sign, d, m, sec), nil
+}
@@ -0,0 +1,184 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleFmtLatitude() {
+	for _, lat := range []unit.Angle{
+		unit.NewAngle('-', 13, 0, 0),
+		unit.NewAngle(' ', 47, 36, 22.5),
+		unit.NewAngle('-', 0, 0, 0.4),
+		unit.NewAngle(' ', 90, 0, 0.1),
+	} {
+		l := sexa.FmtLatitude(lat)
+		fmt.Printf("%s  %#.1s  %2s  %.2h\n", l, l, l, l)
+	}
+	l := sexa.FmtLatitude(unit.AngleFromDeg(91))
+	s := l.String()
+	fmt.Println(s, l.Err)
+	// Output:
+	// 13°0′0″S  13°0′0.0″S  13° 0′ 0″S  13.00°S
+	// 47°36′23″N  47°36′22.5″N  47°36′23″N  47.61°N
+	// 0″N  0°0′0.4″S   0° 0′ 0″N  0.00°N
+	// ***  *********  **********  ******
+	// *** Formatting 91: Value out of range
+}
+
+func TestLatitude(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits:   sexa.UnitSymbols{"d", "m", "s"},
+		DecSep:     ".",
+		NorthSouth: [2]string{" nord", " sud"},
+	}
+	lat := sym.FmtLatitude(unit.NewAngle('-', 1, 2, 3))
+	for _, tc := range []struct{ f, want string }{
+		{"%s", "1d2m3s sud"},
+		{"%+s", "1d2m3s sud"},
+		{"%03s", "001d02m03s sud"},
+		{"%-3s", "1d 2m 3s sud  "},
+		{"%.1m", "1d2.1m sud"},
+	} {
+		if got := fmt.Sprintf(tc.f, lat); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	lat.Angle = unit.AngleFromDeg(-90)
+	if got := fmt.Sprintf("%s", lat); got != "90d0m0s sud" || lat.Err != nil {
+		t.Error(got, lat.Err)
+	}
+	lat.Angle = unit.AngleFromDeg(-90.5)
+	var fe *sexa.FormatError
+	if got := fmt.Sprintf("%s", lat); !errors.Is(lat.Err, sexa.ErrOutOfRange) ||
+		!errors.As(lat.Err, &fe) {
+		t.Error(got, lat.Err)
+	}
+	lat.Angle = unit.Angle(math.Inf(-1))
+	if got := fmt.Sprintf("%s", lat); !errors.Is(lat.Err, sexa.ErrNegInf) {
+		t.Error(got, lat.Err)
+	}
+}
+
+func ExampleFmtLongitude() {
+	for _, lon := range []unit.Angle{
+		unit.NewAngle('-', 122, 19, 59),
+		unit.NewAngle(' ', 190, 0, 0),
+		unit.NewAngle(' ', 180, 0, 0),
+		unit.NewAngle('-', 180, 0, 0),
+		unit.NewAngle(' ', 2, 17, 40.3),
+	} {
+		l := sexa.FmtLongitude(lon)
+		fmt.Printf("%s  %03.1s\n", l, l)
+	}
+	// Output:
+	// 122°19′59″W  122°19′59.0″W
+	// 170°0′0″W  170°00′00.0″W
+	// 180°0′0″E  180°00′00.0″E
+	// 180°0′0″E  180°00′00.0″E
+	// 2°17′40″E  002°17′40.3″E
+}
+
+func TestLongitude(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits: sexa.UnitSymbols{"°", "′", "″"},
+		EastWest: [2]string{" Ost", " West"},
+	}
+	lon := sym.FmtLongitude(unit.AngleFromDeg(-359))
+	if got := fmt.Sprintf("%#3h", lon); got != "  1° Ost" || lon.Err != nil {
+		t.Errorf("got %q, %v", got, lon.Err)
+	}
+	lon.Angle = unit.AngleFromDeg(181)
+	if got := fmt.Sprintf("%m", lon); got != "179°0′ West" {
+		t.Errorf("got %q", got)
+	}
+	lon.Angle = unit.Angle(math.NaN())
+	if got := fmt.Sprintf("%m", lon); !errors.Is(lon.Err, sexa.ErrNaN) {
+		t.Error(got, lon.Err)
+	}
+}
+
+func ExampleParsePackedLat() {
+	lat, err := sexa.ParsePackedLat("404451N")
+	fmt.Println(sexa.FmtLatitude(lat), err)
+	lon, err := sexa.ParsePackedLon("0735959.5W")
+	fmt.Printf("%.1s %v\n", sexa.FmtLongitude(lon), err)
+	// Output:
+	// 40°44′51″N <nil>
+	// 73°59′59.5″W <nil>
+}
+
+func TestParsePacked(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want unit.Angle
+	}{
+		{"000000N", 0},
+		{"000000S", 0},
+		{"900000N", unit.AngleFromDeg(90)},
+		{"900000S", unit.AngleFromDeg(-90)},
+		{"123456.789S", unit.NewAngle('-', 12, 34, 56.789)},
+		{"595959.99N", unit.NewAngle(' ', 59, 59, 59.99)},
+	} {
+		got, err := sexa.ParsePackedLat(tc.s)
+		if err != nil || math.Abs(float64(got-tc.want)) > 1e-15 {
+			t.Errorf("%s: got %v, %v want %v", tc.s, got, err, tc.want)
+		}
+	}
+	for _, tc := range []struct {
+		s    string
+		want unit.Angle
+	}{
+		{"1800000E", unit.AngleFromDeg(180)},
+		{"1800000W", unit.AngleFromDeg(-180)},
+		{"0012345E", unit.NewAngle(' ', 1, 23, 45)},
+		{"1234512.5W", unit.NewAngle('-', 123, 45, 12.5)},
+	} {
+		got, err := sexa.ParsePackedLon(tc.s)
+		if err != nil || math.Abs(float64(got-tc.want)) > 1e-15 {
+			t.Errorf("%s: got %v, %v want %v", tc.s, got, err, tc.want)
+		}
+	}
+	for _, tc := range []struct {
+		s   string
+		lon bool
+		err error
+	}{
+		{"", false, sexa.ErrSyntax},
+		{"40445N", false, sexa.ErrSyntax},
+		{"4044510N", false, sexa.ErrSyntax}, // too many digits
+		{"40x451N", false, sexa.ErrSyntax},
+		{"-04451N", false, sexa.ErrSyntax},
+		{"404451.N", false, sexa.ErrSyntax},
+		{"404451,5N", false, sexa.ErrSyntax},
+		{"404451.5.5N", false, sexa.ErrSyntax},
+		{"4044510", false, sexa.ErrUnitSymbol},
+		{"404451E", false, sexa.ErrUnitSymbol},
+		{"404451n", false, sexa.ErrUnitSymbol},
+		{"406051N", false, sexa.ErrSegmentRange},
+		{"404460N", false, sexa.ErrSegmentRange},
+		{"900001N", false, sexa.ErrSegmentRange},
+		{"900000.1S", false, sexa.ErrSegmentRange},
+		{"910000N", false, sexa.ErrSegmentRange},
+		{"404451N", true, sexa.ErrSyntax},
+		{"0404451N", true, sexa.ErrUnitSymbol},
+		{"1800001E", true, sexa.ErrSegmentRange},
+		{"1810000W", true, sexa.ErrSegmentRange},
+	} {
+		parse := sexa.ParsePackedLat
+		if tc.lon {
+			parse = sexa.ParsePackedLon
+		}
+		var pe *sexa.ParseError
+		if _, err := parse(tc.s); !errors.Is(err, tc.err) || !errors.As(err, &pe) {
+			t.Errorf("%q: got %v want %v", tc.s, err, tc.err)
+		}
+	}
+}
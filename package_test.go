@@ -169,11 +169,11 @@ func Example_withOverflow() {
 	// Output:
 	// | 35° 0′ 0″|
 	// |**********|
-	// Err: Degrees overflow width
+	// Err: Formatting 135 in width 2: Degrees overflow width
 	//
 	// | 12ʰ 0ᵐ|
 	// |*******|
-	// Err: Hours overflow width
+	// Err: Formatting 125 in width 2: Hours overflow width
 }
 
 func Example_withInvalidVerb() {
@@ -186,15 +186,19 @@ func Example_withInvalidVerb() {
 }
 
 func Example_withLossOfPrecision() {
-	f := sexa.FmtAngle(unit.NewAngle(' ', 135, 0, 0))
+	f := sexa.FmtAngle(unit.NewAngle(' ', 135, 0, .1))
 	fmt.Printf("%.16s\n", f) // 16 is always too much to ask
 	fmt.Printf("%.10s ", f)  // but 10 is still too much for 135°
 	fmt.Println(f.Err)
 	fmt.Printf("%.9s\n", f) // 9 is ok.  all digits are significant.
+	// an exact value has all digits significant
+	f.Angle = unit.NewAngle(' ', 135, 0, 0)
+	fmt.Printf("%.10s\n", f)
 	// Output:
 	// %!(BADPREC 16)
-	// ************* Loss of precision
-	// 135°0′0.000000000″
+	// ************* Formatting 135.00002777777777 at precision 10: Loss of precision
+	// 135°0′0.100000000″
+	// 135°0′0.0000000000″
 }
 
 func Example_withInfNaN() {
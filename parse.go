@@ -0,0 +1,555 @@
+// License: MIT
+
+package sexa
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/soniakeys/unit"
+)
+
+// Predefined errors indicate why a string could not be parsed.
+// Parsing functions return them wrapped in a *ParseError.
+var (
+	ErrSyntax       = errors.New("Invalid syntax")
+	ErrSegmentRange = errors.New("Segment out of range")
+	ErrUnitSymbol   = errors.New("Missing or invalid unit symbol")
+	ErrSign         = errors.New("Unexpected sign")
+)
+
+// ParseError records a failed parse.
+type ParseError struct {
+	Input string // the input being parsed
+	Err   error  // the reason the parse failed, for example ErrSyntax
+}
+
+func (e *ParseError) Error() string {
+	return "Parsing " + strconv.Quote(e.Input) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the reason the parse failed.
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// parseTolerance parses a single decimal segment with a unit of sym.DMSUnits,
+// such as "1″" or "0.1′".
+//
+// It returns the verb with the segment as the decimal segment and the
+// precision needed to show the tolerance.
+func (sym *Symbols) parseTolerance(tol string) (verb rune, prec int, err error) {
+	units := []struct {
+		u string
+		v rune
+	}{
+		{sym.DMSUnits.Sec, secAppend},
+		{sym.DMSUnits.Min, minAppend},
+		{sym.DMSUnits.HrDeg, hrDegAppend},
+	}
+	for _, u := range units {
+		if u.u == "" || !strings.HasSuffix(tol, u.u) {
+			continue
+		}
+		x, err := strconv.ParseFloat(strings.TrimSuffix(tol, u.u), 64)
+		if err != nil || !(x > 0) || x > 1e300 {
+			break
+		}
+		// smallest precision with a resolution of no more than x
+		for r := 1.; r > x*(1+1e-9) && prec < 15; r /= 10 {
+			prec++
+		}
+		return u.v, prec, nil
+	}
+	return 0, 0, &ParseError{tol, ErrSyntax}
+}
+
+// Glyph classes of parseFlexible.  Values are not valid runes and so
+// cannot be confused with input.
+const (
+	flexDeg = -1 - iota
+	flexMin
+	flexSec
+	flexCombine
+)
+
+// flexGlyph classifies runes accepted by parseFlexible.
+//
+// Units are normalized from the assorted glyphs commonly used for them.
+// The glyph '\u0323', combining dot below, is the combining decimal separator.
+func flexGlyph(r rune) rune {
+	switch r {
+	case '°', 'º', '˚', 'd', 'D':
+		return flexDeg
+	case '′', '\'', '’', '‘', 'ʹ', 'm', 'M':
+		return flexMin
+	case '″', '"', '”', '“', 'ʺ', 's', 'S':
+		return flexSec
+	case '-', '−':
+		return '-'
+	case '.', ',':
+		return '.'
+	case '\u0323':
+		return flexCombine
+	}
+	if unicode.IsSpace(r) {
+		return ' '
+	}
+	return r
+}
+
+// parseFlexible leniently parses degrees, minutes, and seconds.
+//
+// Segments can be separated by unit symbols in any of several glyphs,
+// by colons, or by white space.  A unit symbol can also precede the decimal
+// separator, as with the inserted and combined conventions.  Minutes or
+// seconds of 60 or more give ErrSegmentRange rather than ErrSyntax.
+func parseFlexible(s string) (unit.Angle, error) {
+	// normalize glyphs, making a pair of apostrophes a seconds symbol.
+	g := []rune(strings.TrimSpace(strings.Replace(s, "''", "″", -1)))
+	for i, r := range g {
+		g[i] = flexGlyph(r)
+	}
+	fail := &ParseError{s, ErrSyntax}
+	neg := false
+	if len(g) > 0 && (g[0] == '-' || g[0] == '+') {
+		neg = g[0] == '-'
+		g = g[1:]
+	}
+	var seg [3]float64
+	pos := -1     // position of last segment, 0 for degrees
+	frac := false // last segment had a fraction
+	for len(g) > 0 {
+		if frac {
+			return 0, fail // only the last segment can have a fraction
+		}
+		// number
+		n := 0
+		for n < len(g) && (g[n] >= '0' && g[n] <= '9' || g[n] == '.') {
+			n++
+		}
+		num := string(g[:n])
+		if n == 0 || strings.Count(num, ".") > 1 {
+			return 0, fail
+		}
+		frac = strings.Contains(num, ".")
+		g = g[n:]
+		// separator
+		sp := 0
+		for sp < len(g) && g[sp] == ' ' {
+			sp++
+		}
+		g = g[sp:]
+		p := pos + 1
+		if len(g) > 0 && (sp == 0 || g[0] < '0' || g[0] > '9') {
+			switch g[0] {
+			case flexDeg:
+				p = 0
+			case flexMin:
+				p = 1
+			case flexSec:
+				p = 2
+			case ':':
+			default:
+				return 0, fail
+			}
+			g = g[1:]
+			// fraction following a unit
+			if len(g) > 1 && (g[0] == '.' || g[0] == flexCombine) && !frac &&
+				g[1] >= '0' && g[1] <= '9' {
+				g[0] = '.'
+				n = 1
+				for n < len(g) && g[n] >= '0' && g[n] <= '9' {
+					n++
+				}
+				num += string(g[:n])
+				frac = true
+				g = g[n:]
+			}
+			for len(g) > 0 && g[0] == ' ' {
+				g = g[1:]
+			}
+		}
+		if p <= pos || p > 2 {
+			return 0, fail
+		}
+		x, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, fail
+		}
+		if pos >= 0 && x >= 60 {
+			return 0, &ParseError{s, ErrSegmentRange}
+		}
+		seg[p] = x
+		pos = p
+	}
+	if pos < 0 {
+		return 0, fail
+	}
+	sec := (seg[0]*60+seg[1])*60 + seg[2]
+	if neg {
+		sec = -sec
+	}
+	return unit.AngleFromSec(sec), nil
+}
+
+// ParseAngleFlexible leniently parses an angle as people commonly type one,
+// as in "12 34 45", "12:34:45", "12d34m45s", or `12°34'45"N`.
+//
+// Segments can be separated by any of the common glyphs for the units, by
+// colons, or by white space, as for CanonicalizeAngle.  The angle can have
+// an optional leading sign, or a hemisphere letter N, S, E, or W, leading or
+// trailing.  S and W give negative angles.  A trailing S is taken as a
+// seconds symbol rather than south if the input uses a letter M or m for
+// minutes, as in "12D34M45S".
+//
+// An input that cannot be parsed gives a *ParseError wrapping ErrSyntax,
+// ErrSegmentRange for minutes or seconds of 60 or more, or ErrSign for both
+// a sign and a hemisphere letter.
+func ParseAngleFlexible(s string) (unit.Angle, error) {
+	t, neg, hemi := cutHemisphere(strings.TrimSpace(s))
+	if hemi {
+		if _, _, again := cutHemisphere(t); again {
+			return 0, &ParseError{s, ErrSyntax}
+		}
+		if strings.IndexAny(t, "+-−") == 0 {
+			return 0, &ParseError{s, ErrSign}
+		}
+	}
+	a, err := parseFlexible(t)
+	if err != nil {
+		return 0, &ParseError{s, err.(*ParseError).Err}
+	}
+	if neg {
+		a = -a
+	}
+	return a, nil
+}
+
+// cutHemisphere removes a leading or trailing hemisphere letter from t,
+// reporting whether it was found and whether it indicates a negative angle.
+func cutHemisphere(t string) (rest string, neg, ok bool) {
+	if t == "" {
+		return t, false, false
+	}
+	if i := strings.IndexByte("NEWS", t[0]); i >= 0 {
+		return strings.TrimSpace(t[1:]), i >= 2, true
+	}
+	last := t[len(t)-1]
+	i := strings.IndexByte("NEWS", last)
+	if i < 0 || last == 'S' && strings.ContainsAny(t, "Mm") {
+		return t, false, false
+	}
+	return strings.TrimSpace(t[:len(t)-1]), i >= 2, true
+}
+
+// ParseAngle parses an angle formatted with the default symbols.
+//
+// It accepts the output of the custom formatter of Angle with any of the
+// verbs, with or without flags or width.  That is, the string has an optional
+// sign followed by one to three segments, each a number and a unit symbol of
+// Default.DMSUnits.  Leading segments can be elided.  The last segment can
+// have a decimal fraction, using any of the three decimal unit conventions.
+//
+// Minutes and seconds segments must be less than 60.  Errors are returned as
+// a *ParseError.
+func ParseAngle(s string) (unit.Angle, error) {
+	return defaultSymbols().ParseAngle(s)
+}
+
+// ParseAngle parses an angle formatted with the symbols of sym.
+//
+// Segments are recognized by the unit symbols of sym.DMSUnits, which can be
+// multiple character strings, including strings with spaces.  The decimal
+// separator is recognized by sym.DecSep, or sym.DecCombine following a unit.
+// Thus output of sym.FmtAngle can be parsed with sym.ParseAngle.
+// See the top level function ParseAngle for more detail.
+func (sym *Symbols) ParseAngle(s string) (unit.Angle, error) {
+	sec, err := sym.parse(s, sym.DMSUnits)
+	return unit.AngleFromSec(sec), err
+}
+
+// ParseHourAngle parses an hour angle formatted with the default symbols.
+//
+// It accepts the output of the custom formatter of HourAngle, with segments
+// using the unit symbols of Default.HMSUnits.  See ParseAngle.
+//
+// The *ParseError returned on failure wraps ErrUnitSymbol if a number is not
+// followed by an expected unit symbol, and ErrSegmentRange if minutes or
+// seconds are 60 or more.
+func ParseHourAngle(s string) (unit.HourAngle, error) {
+	return defaultSymbols().ParseHourAngle(s)
+}
+
+// ParseHourAngle parses an hour angle formatted with the symbols of sym.
+// See Symbols.ParseAngle.
+func (sym *Symbols) ParseHourAngle(s string) (unit.HourAngle, error) {
+	sec, err := sym.parse(s, sym.HMSUnits)
+	return unit.HourAngleFromSec(sec), err
+}
+
+// ParseRA parses a right ascension formatted with the default symbols.
+//
+// It accepts the output of the custom formatter of RA, with segments using
+// the unit symbols of Default.HMSUnits.  See ParseAngle.  Right ascension has
+// no sign.  A string with a sign gives a *ParseError wrapping ErrSign.
+// Values are normalized to the range 0 to 24 hours, so that "25ʰ0ᵐ0ˢ" parses
+// as 1ʰ.
+func ParseRA(s string) (unit.RA, error) {
+	return defaultSymbols().ParseRA(s)
+}
+
+// ParseRA parses a right ascension formatted with the symbols of sym.
+// See Symbols.ParseAngle and the top level function ParseRA.
+func (sym *Symbols) ParseRA(s string) (unit.RA, error) {
+	if sym.hasSign(s) {
+		return 0, &ParseError{s, ErrSign}
+	}
+	sec, err := sym.parse(s, sym.HMSUnits)
+	return unit.RAFromSec(sec), err
+}
+
+// ParseRAToDeg parses a right ascension formatted with the default symbols,
+// as ParseRA does, and returns it in degrees, so that "12ʰ30ᵐ0ˢ" gives 187.5.
+// The conversion is that of unit.RA.Deg, by way of radians, so the result
+// can differ from an exact multiple of 15 in the last bits.  On error it
+// returns 0 and the error of ParseRA.
+func ParseRAToDeg(s string) (float64, error) {
+	return defaultSymbols().ParseRAToDeg(s)
+}
+
+// ParseRAToDeg parses a right ascension formatted with the symbols of sym
+// and returns it in degrees.  See the top level function ParseRAToDeg.
+func (sym *Symbols) ParseRAToDeg(s string) (float64, error) {
+	ra, err := sym.ParseRA(s)
+	if err != nil {
+		return 0, err
+	}
+	return ra.Deg(), nil
+}
+
+// ParseTime parses a time formatted with the default symbols.
+//
+// It accepts the output of the custom formatter of Time, with segments using
+// the unit symbols of Default.HMSUnits.  See ParseAngle and Symbols.ParseTime.
+func ParseTime(s string) (unit.Time, error) {
+	return defaultSymbols().ParseTime(s)
+}
+
+// ParseTime parses a time formatted with the symbols of sym.
+//
+// Segments are recognized by the unit symbols of sym.HMSUnits.  These can
+// be separators such as ":" rather than unit symbols.  An empty unit symbol
+// matches the end of a segment without a symbol, so that with HMSUnits of
+// {":", ":", ""}, "-0:22:07" parses as 22ᵐ7ˢ negative.  Segments with a
+// symbol repeated for consecutive segments, such as ":", are assigned counting
+// back from the last segment, so that output with leading zero segments
+// elided, "-22:07" for example, parses to the same value.
+//
+// Minutes and seconds segments must be less than 60.  Errors are returned as
+// a *ParseError.
+func (sym *Symbols) ParseTime(s string) (unit.Time, error) {
+	sec, err := sym.parse(s, sym.HMSUnits)
+	return unit.Time(sec), err
+}
+
+// MustParseAngle is like ParseAngle but panics if s cannot be parsed.
+//
+// It simplifies initialization of variables holding angles, as with
+// regexp.MustCompile.  The panic value is an error wrapping the *ParseError,
+// which identifies the input.
+func MustParseAngle(s string) unit.Angle {
+	a, err := ParseAngle(s)
+	if err != nil {
+		panic(fmt.Errorf("sexa: MustParseAngle: %w", err))
+	}
+	return a
+}
+
+// MustParseHourAngle is like ParseHourAngle but panics if s cannot be parsed.
+// See MustParseAngle.
+func MustParseHourAngle(s string) unit.HourAngle {
+	h, err := ParseHourAngle(s)
+	if err != nil {
+		panic(fmt.Errorf("sexa: MustParseHourAngle: %w", err))
+	}
+	return h
+}
+
+// MustParseRA is like ParseRA but panics if s cannot be parsed.
+// See MustParseAngle.
+func MustParseRA(s string) unit.RA {
+	ra, err := ParseRA(s)
+	if err != nil {
+		panic(fmt.Errorf("sexa: MustParseRA: %w", err))
+	}
+	return ra
+}
+
+// MustParseTime is like ParseTime but panics if s cannot be parsed.
+// See MustParseAngle.
+func MustParseTime(s string) unit.Time {
+	t, err := ParseTime(s)
+	if err != nil {
+		panic(fmt.Errorf("sexa: MustParseTime: %w", err))
+	}
+	return t
+}
+
+// hasSign reports whether s starts with a sign other than SignPad,
+// after any leading spaces.
+func (sym *Symbols) hasSign(s string) bool {
+	s = strings.TrimLeft(s, " ")
+	return strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") ||
+		sym.NegSign > "" && strings.HasPrefix(s, sym.NegSign) ||
+		sym.PosSign > "" && strings.HasPrefix(s, sym.PosSign)
+}
+
+// parse parses s as formatted with units and the decimal separators of sym.
+// It returns the value in seconds, arc seconds or seconds of time.
+func (sym *Symbols) parse(s string, units UnitSymbols) (float64, error) {
+	fail := func(err error) (float64, error) {
+		return 0, &ParseError{s, err}
+	}
+	r := strings.TrimLeft(s, " ")
+	neg := false
+	switch {
+	case sym.NegSign > "" && strings.HasPrefix(r, sym.NegSign):
+		r, neg = r[len(sym.NegSign):], true
+	case sym.PosSign > "" && strings.HasPrefix(r, sym.PosSign):
+		r = r[len(sym.PosSign):]
+	case sym.SignPad > "" && strings.HasPrefix(r, sym.SignPad):
+		r = r[len(sym.SignPad):]
+	case r > "" && r[0] == '-':
+		r, neg = r[1:], true
+	case r > "" && r[0] == '+':
+		r = r[1:]
+	}
+	u := [3]string{units.HrDeg, units.Min, units.Sec}
+	var qs [3]int     // positions of the segments parsed
+	var xs [3]float64 // values of the segments parsed
+	k := 0            // number of segments parsed
+	p := 0            // next position that can be parsed
+	for p < 3 {
+		// number, possibly space padded
+		r = strings.TrimLeft(r, " ")
+		n := 0
+		for n < len(r) && r[n] >= '0' && r[n] <= '9' {
+			n++
+		}
+		if n == 0 {
+			return fail(ErrSyntax)
+		}
+		num := r[:n]
+		r = r[n:]
+		frac := false
+		if f := sym.fraction(r); f > 0 {
+			num += "." + r[len(sym.DecSep):f]
+			r = r[f:]
+			frac = true
+		}
+		// unit, the first that matches of those remaining
+		q := p
+		for ; q < 3; q++ {
+			if u[q] > "" && strings.HasPrefix(r, u[q]) {
+				break
+			}
+		}
+		if q == 3 {
+			// no unit found.  an empty unit matches
+			for q = p; q < 3 && u[q] > ""; q++ {
+			}
+			if q == 3 {
+				return fail(ErrUnitSymbol)
+			}
+		}
+		r = r[len(u[q]):]
+		if !frac {
+			// decimal separator following the unit
+			if f := sym.fraction(r); f > 0 {
+				num += "." + r[len(sym.DecSep):f]
+				r = r[f:]
+				frac = true
+			} else if sz, f := sym.combined(r); f > 0 {
+				num += "." + r[sz:f]
+				r = r[f:]
+				frac = true
+			}
+			if frac && sym.DoubleUnit {
+				r = strings.TrimPrefix(r, u[q])
+			}
+		}
+		x, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return fail(ErrSyntax)
+		}
+		qs[k], xs[k] = q, x
+		k++
+		p = q + 1
+		if frac || r == "" {
+			break
+		}
+	}
+	if r > "" {
+		return fail(ErrSyntax)
+	}
+	// a unit symbol repeated for consecutive segments, as with separators
+	// such as ":", matches the first of them.  when the input ends with the
+	// last segment, leading segments may have been elided, so segments
+	// with such a symbol are assigned counting back from the end instead.
+	if qs[k-1] == 2 {
+		for i := k - 2; i >= 0; i-- {
+			if t := qs[i+1] - 1; t > qs[i] && u[t] == u[qs[i]] {
+				qs[i] = t
+			}
+		}
+	}
+	var seg [3]float64
+	for i, q := range qs[:k] {
+		if q > 0 && xs[i] >= 60 {
+			return fail(ErrSegmentRange)
+		}
+		seg[q] = xs[i]
+	}
+	sec := (seg[0]*60+seg[1])*60 + seg[2]
+	if neg {
+		sec = -sec
+	}
+	return sec, nil
+}
+
+// fraction returns the length of a decimal separator and following digits
+// at the start of r, or 0 if r does not start with them.
+func (sym *Symbols) fraction(r string) int {
+	if sym.DecSep == "" || !strings.HasPrefix(r, sym.DecSep) {
+		return 0
+	}
+	f := len(sym.DecSep)
+	for f < len(r) && r[f] >= '0' && r[f] <= '9' {
+		f++
+	}
+	if f == len(sym.DecSep) {
+		return 0
+	}
+	return f
+}
+
+// combined is the inverse of CombineUnit.  If r starts with sym.DecCombine
+// and following digits, it returns the byte length of the DecCombine rune and
+// the length of the rune and digits together.  Otherwise it returns 0, 0.
+func (sym *Symbols) combined(r string) (sz, f int) {
+	c, sz := utf8.DecodeRuneInString(r)
+	if sym.DecCombine == 0 || c != sym.DecCombine {
+		return 0, 0
+	}
+	f = sz
+	for f < len(r) && r[f] >= '0' && r[f] <= '9' {
+		f++
+	}
+	if f == sz {
+		return 0, 0
+	}
+	return sz, f
+}
@@ -0,0 +1,432 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleParseAngle() {
+	for _, s := range []string{
+		"12°34′45.6″",
+		"12°34′45″.6",
+		"12°34′45″̣6",
+		"-1′2″",
+		"12°60′0″",
+		"12°34′45″ junk",
+	} {
+		a, err := sexa.ParseAngle(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("%.1s\n", sexa.FmtAngle(a))
+	}
+	// Output:
+	// 12°34′45.6″
+	// 12°34′45.6″
+	// 12°34′45.6″
+	// -1′2.0″
+	// Parsing "12°60′0″": Segment out of range
+	// Parsing "12°34′45″ junk": Invalid syntax
+}
+
+// ParseAngle reverses formatting with the default symbols.
+func TestParseAngleRoundTrip(t *testing.T) {
+	for _, a := range []unit.Angle{
+		unit.NewAngle(' ', 12, 34, 45.6),
+		unit.NewAngle('-', 0, 1, 2.3),
+		unit.NewAngle('-', 0, 0, 2.3),
+		unit.NewAngle(' ', 359, 59, 59.9),
+		0,
+	} {
+		for _, f := range []string{
+			"%.1s", "%.1c", "%.1d", "%+.1s", "% .1s", "%#0.1s", "%3.1s",
+			"%03.1s", "%.3m", "%.3n", "%.3o", "%.5h", "%.5i", "%.5j", "%4.5h",
+		} {
+			s := fmt.Sprintf(f, sexa.FmtAngle(a))
+			p, err := sexa.ParseAngle(s)
+			if err != nil {
+				t.Errorf("%s %q: %v", f, s, err)
+				continue
+			}
+			if math.Abs(p.Sec()-a.Sec()) > .05 {
+				t.Errorf("%s %q: parsed %v″ want %v″", f, s, p.Sec(), a.Sec())
+			}
+		}
+	}
+}
+
+func TestParseAngleErrors(t *testing.T) {
+	for _, s := range []string{
+		"", "-", "°", "12", "12°34", "12°34′45.6", "12.5°34′", "12°34′45″6",
+		"12°34′45″.", "34′12°", "12°34′45″ ", "1°2′3″4″",
+	} {
+		_, err := sexa.ParseAngle(s)
+		var pe *sexa.ParseError
+		if !errors.As(err, &pe) || pe.Input != s {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+	for _, s := range []string{"60′", "1°60′", "1°2′60″", "59′60.0″"} {
+		if _, err := sexa.ParseAngle(s); !errors.Is(err, sexa.ErrSegmentRange) {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+}
+
+func ExampleParseHourAngle() {
+	for _, s := range []string{
+		"-1ʰ47ᵐ22ˢ",
+		"+1ʰ47ᵐ22ˢ",
+		" 1ʰ47ᵐ22ˢ",
+		"47ᵐ22.5ˢ",
+		"1h47m22s",
+		"1ʰ47ᵐ72ˢ",
+	} {
+		h, err := sexa.ParseHourAngle(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("%.1s\n", sexa.FmtHourAngle(h))
+	}
+	// Output:
+	// -1ʰ47ᵐ22.0ˢ
+	// 1ʰ47ᵐ22.0ˢ
+	// 1ʰ47ᵐ22.0ˢ
+	// 47ᵐ22.5ˢ
+	// Parsing "1h47m22s": Missing or invalid unit symbol
+	// Parsing "1ʰ47ᵐ72ˢ": Segment out of range
+}
+
+func TestParseHourAngleErrors(t *testing.T) {
+	for _, s := range []string{"1", "1ʰ47", "1ʰ47°", "1°"} {
+		if _, err := sexa.ParseHourAngle(s); !errors.Is(err, sexa.ErrUnitSymbol) {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+	for _, s := range []string{"1ʰ60ᵐ", "1ʰ0ᵐ60ˢ", "99ᵐ"} {
+		if _, err := sexa.ParseHourAngle(s); !errors.Is(err, sexa.ErrSegmentRange) {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "ʰ", "1ʰ2ᵐ3ˢ4", "1ʰ2ᵐ3ˢx"} {
+		if _, err := sexa.ParseHourAngle(s); !errors.Is(err, sexa.ErrSyntax) {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+}
+
+func ExampleParseRA() {
+	for _, s := range []string{
+		"12ʰ34ᵐ45.6ˢ",
+		"25ʰ0ᵐ0ˢ",
+		" 1ʰ 2ᵐ 3ˢ",
+		"-1ʰ0ᵐ0ˢ",
+	} {
+		ra, err := sexa.ParseRA(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("%.1s\n", sexa.FmtRA(ra))
+	}
+	// Output:
+	// 12ʰ34ᵐ45.6ˢ
+	// 1ʰ0ᵐ0.0ˢ
+	// 1ʰ2ᵐ3.0ˢ
+	// Parsing "-1ʰ0ᵐ0ˢ": Unexpected sign
+}
+
+func ExampleParseRAToDeg() {
+	d, err := sexa.ParseRAToDeg("12ʰ30ᵐ00ˢ")
+	fmt.Printf("%.4f %v\n", d, err)
+	_, err = sexa.ParseRAToDeg("12ʰ60ᵐ00ˢ")
+	fmt.Println(err)
+	// Output:
+	// 187.5000 <nil>
+	// Parsing "12ʰ60ᵐ00ˢ": Segment out of range
+}
+
+func TestParseRAToDeg(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want float64
+	}{
+		{"0ʰ0ᵐ0ˢ", 0},
+		{"1ʰ", 15},
+		{"6ʰ0ᵐ0.0ˢ", 90},
+		{"25ʰ0ᵐ0ˢ", 15}, // normalized as with ParseRA
+	} {
+		got, err := sexa.ParseRAToDeg(tc.s)
+		if err != nil || math.Abs(got-tc.want) > 1e-12 {
+			t.Errorf("%q: got %v, %v want %v", tc.s, got, err, tc.want)
+		}
+	}
+	got, err := sexa.ASCII.ParseRAToDeg("-1h")
+	if got != 0 || !errors.Is(err, sexa.ErrSign) {
+		t.Errorf("got %v, %v", got, err)
+	}
+}
+
+func ExampleParseTime() {
+	t, err := sexa.ParseTime("1ᵐ30ˢ")
+	fmt.Println(t.Sec(), err)
+	_, err = sexa.ParseTime("1ᵐ70ˢ")
+	fmt.Println(err)
+	// Output:
+	// 90 <nil>
+	// Parsing "1ᵐ70ˢ": Segment out of range
+}
+
+func ExampleSymbols_ParseTime() {
+	clock := &sexa.Symbols{HMSUnits: sexa.UnitSymbols{":", ":", ""}, DecSep: "."}
+	for _, s := range []string{"-0:22:07", "1:02:03", "12:34:56.5", "0:61:00"} {
+		t, err := clock.ParseTime(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(t.Sec(), sexa.FmtTime(t))
+	}
+	// Output:
+	// -1327 -22ᵐ7ˢ
+	// 3723 1ʰ2ᵐ3ˢ
+	// 45296.5 12ʰ34ᵐ57ˢ
+	// Parsing "0:61:00": Segment out of range
+}
+
+func ExampleSymbols_ParseAngle() {
+	s := sexa.Symbols{DMSUnits: sexa.UnitSymbols{"d ", "m ", "s"}, DecSep: "."}
+	f := fmt.Sprintf("%.1s", s.FmtAngle(unit.NewAngle('-', 13, 47, 22.5)))
+	fmt.Println(f)
+	a, err := s.ParseAngle(f)
+	fmt.Printf("%.1s %v\n", s.FmtAngle(a), err)
+	// Output:
+	// -13d 47m 22.5s
+	// -13d 47m 22.5s <nil>
+}
+
+// Output of the Fmt methods of Symbols round trips through the Parse methods.
+func TestSymbolsParseRoundTrip(t *testing.T) {
+	for _, sym := range []*sexa.Symbols{
+		{DMSUnits: sexa.UnitSymbols{"d ", "m ", "s"},
+			HMSUnits: sexa.UnitSymbols{"hr ", "min ", "sec"}, DecSep: "."},
+		{DMSUnits: sexa.UnitSymbols{"deg", "'", "\""},
+			HMSUnits: sexa.UnitSymbols{"h", "m", "s"}, DecSep: ","},
+		{DMSUnits: sexa.UnitSymbols{" ", " ", ""},
+			HMSUnits: sexa.UnitSymbols{":", ":", ""}, DecSep: "."},
+		{DMSUnits: sexa.UnitSymbols{"°", "′", "″"},
+			HMSUnits: sexa.UnitSymbols{"ʰ", "ᵐ", "ˢ"},
+			DecSep:   ",", DecCombine: '\u0326'},
+	} {
+		a := unit.NewAngle('-', 13, 47, 22.5)
+		ha := unit.NewHourAngle('-', 1, 47, 22.5)
+		ra := unit.NewRA(1, 47, 22.5)
+		for _, f := range []string{"%.1s", "%.1c", "%.1d", "%#0.1s", "%2.1s"} {
+			s := fmt.Sprintf(f, sym.FmtAngle(a))
+			if p, err := sym.ParseAngle(s); err != nil ||
+				math.Abs(p.Sec()-a.Sec()) > 1e-6 {
+				t.Errorf("%s %q: %v %v", f, s, p.Sec(), err)
+			}
+			s = fmt.Sprintf(f, sym.FmtHourAngle(ha))
+			if p, err := sym.ParseHourAngle(s); err != nil ||
+				math.Abs(p.Sec()-ha.Sec()) > 1e-6 {
+				t.Errorf("%s %q: %v %v", f, s, p.Sec(), err)
+			}
+			s = fmt.Sprintf(f, sym.FmtRA(ra))
+			if p, err := sym.ParseRA(s); err != nil ||
+				math.Abs(p.Sec()-ra.Sec()) > 1e-6 {
+				t.Errorf("%s %q: %v %v", f, s, p.Sec(), err)
+			}
+		}
+	}
+}
+
+// Output with leading zero segments elided round trips, including with
+// separator symbols repeated for consecutive segments.
+func TestParseElidedRoundTrip(t *testing.T) {
+	colon, _ := sexa.Style("colon")
+	for _, sym := range []*sexa.Symbols{
+		colon,
+		{DMSUnits: sexa.UnitSymbols{" ", " ", ""},
+			HMSUnits: sexa.UnitSymbols{":", ":", "s"}, DecSep: "."},
+		sexa.ASCII,
+	} {
+		for _, sec := range []float64{0, 7, -7.5, 59.5, 60, -1327, 3723,
+			-45296.5} {
+			for _, f := range []string{"%.1s", "%s", "%#.1s"} {
+				tm := sym.FmtTime(unit.Time(sec))
+				s := fmt.Sprintf(f, tm)
+				p, err := sym.ParseTime(s)
+				if got := fmt.Sprintf(f, sym.FmtTime(p)); err != nil ||
+					got != s {
+					t.Errorf("%s %q: got %q, %v", f, s, got, err)
+				}
+				a := sym.FmtAngle(unit.AngleFromSec(sec))
+				s = fmt.Sprintf(f, a)
+				pa, err := sym.ParseAngle(s)
+				if got := fmt.Sprintf(f, sym.FmtAngle(pa)); err != nil ||
+					got != s {
+					t.Errorf("%s %q: got %q, %v", f, s, got, err)
+				}
+			}
+		}
+	}
+	// the reported case
+	colonTime, err := colon.ParseTime("-22:7")
+	if err != nil || colonTime.Sec() != -1327 {
+		t.Errorf("got %v, %v", colonTime.Sec(), err)
+	}
+}
+
+// Each of the three decimal unit conventions round trips, for each segment
+// that can hold the decimal.
+func TestParseDecimalConventions(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits:   sexa.UnitSymbols{"°", "′", "″"},
+		HMSUnits:   sexa.UnitSymbols{"ʰ", "ᵐ", "ˢ"},
+		DecSep:     ",",
+		DecCombine: '\u0326', // combining comma below, two bytes
+	}
+	a := unit.NewAngle('-', 12, 34, 45.6)
+	h := unit.NewHourAngle('-', 1, 2, 3.4)
+	ra := unit.NewRA(1, 2, 3.4)
+	tm := unit.NewTime(' ', 1, 2, 3.4)
+	for _, s := range []*sexa.Symbols{sexa.Default, sym} {
+		for _, v := range []string{"s", "c", "d", "m", "n", "o", "h", "i", "j"} {
+			f := "%.3" + v
+			tol := 1e-3
+			switch v {
+			case "m", "n", "o":
+				tol = .06
+			case "h", "i", "j":
+				tol = 3.6
+			}
+			out := fmt.Sprintf(f, s.FmtAngle(a))
+			if p, err := s.ParseAngle(out); err != nil ||
+				math.Abs(p.Sec()-a.Sec()) > tol {
+				t.Errorf("%s %q: %v %v", f, out, p.Sec(), err)
+			}
+			out = fmt.Sprintf(f, s.FmtHourAngle(h))
+			if p, err := s.ParseHourAngle(out); err != nil ||
+				math.Abs(p.Sec()-h.Sec()) > tol {
+				t.Errorf("%s %q: %v %v", f, out, p.Sec(), err)
+			}
+			out = fmt.Sprintf(f, s.FmtRA(ra))
+			if p, err := s.ParseRA(out); err != nil ||
+				math.Abs(p.Sec()-ra.Sec()) > tol {
+				t.Errorf("%s %q: %v %v", f, out, p.Sec(), err)
+			}
+			out = fmt.Sprintf(f, s.FmtTime(tm))
+			if p, err := s.ParseTime(out); err != nil ||
+				math.Abs(p.Sec()-tm.Sec()) > tol {
+				t.Errorf("%s %q: %v %v", f, out, p.Sec(), err)
+			}
+		}
+	}
+	// a combining mark not followed by digits is not a decimal separator
+	for _, s := range []string{"12°34′45″̣", "12°34′45″̣x", "12°34′̣45″"} {
+		if _, err := sexa.ParseAngle(s); err == nil {
+			t.Errorf("%q parsed", s)
+		}
+	}
+}
+
+func ExampleParseAngleFlexible() {
+	for _, s := range []string{
+		"12 34 45",
+		"12:34:45",
+		"12d34m45s",
+		`12°34'45"S`,
+		"W 73 59 30",
+	} {
+		a, err := sexa.ParseAngleFlexible(s)
+		fmt.Println(sexa.FmtAngle(a), err)
+	}
+	// Output:
+	// 12°34′45″ <nil>
+	// 12°34′45″ <nil>
+	// 12°34′45″ <nil>
+	// -12°34′45″ <nil>
+	// -73°59′30″ <nil>
+}
+
+func TestParseAngleFlexible(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want unit.Angle
+	}{
+		{"-12 30", unit.NewAngle('-', 12, 30, 0)},
+		{"+12:30", unit.NewAngle(' ', 12, 30, 0)},
+		{"12.5N", unit.NewAngle(' ', 12, 30, 0)},
+		{" 12 30 E ", unit.NewAngle(' ', 12, 30, 0)},
+		{"N12°30′", unit.NewAngle(' ', 12, 30, 0)},
+		{"12 30 0 S", unit.NewAngle('-', 12, 30, 0)},
+		{"12D30M5S", unit.NewAngle(' ', 12, 30, 5)}, // S is seconds
+	} {
+		got, err := sexa.ParseAngleFlexible(tc.in)
+		if err != nil || math.Abs((got-tc.want).Sec()) > 1e-9 {
+			t.Errorf("%q: got %v, %v want %v", tc.in, got, err, tc.want)
+		}
+	}
+	for _, tc := range []struct {
+		in  string
+		err error
+	}{
+		{"", sexa.ErrSyntax},
+		{"N", sexa.ErrSyntax},
+		{"12 30 X", sexa.ErrSyntax},
+		{"N12 30 S", sexa.ErrSyntax},
+		{"-12 30 S", sexa.ErrSign},
+		{"W+12", sexa.ErrSign},
+		{"12:34:75N", sexa.ErrSegmentRange},
+	} {
+		_, err := sexa.ParseAngleFlexible(tc.in)
+		var pe *sexa.ParseError
+		if !errors.As(err, &pe) || pe.Input != tc.in || !errors.Is(err, tc.err) {
+			t.Errorf("%q: got %v want %v", tc.in, err, tc.err)
+		}
+	}
+}
+
+func ExampleMustParseAngle() {
+	var obliquity = sexa.MustParseAngle("23°26′21.448″")
+	fmt.Printf("%.6f\n", obliquity.Deg())
+	defer func() { fmt.Println(recover()) }()
+	sexa.MustParseAngle("23°26′61″")
+	// Output:
+	// 23.439291
+	// sexa: MustParseAngle: Parsing "23°26′61″": Segment out of range
+}
+
+func TestMustParse(t *testing.T) {
+	if sexa.MustParseHourAngle("-1ʰ2ᵐ3ˢ") != unit.NewHourAngle('-', 1, 2, 3) ||
+		sexa.MustParseRA("1ʰ2ᵐ3ˢ") != unit.NewRA(1, 2, 3) ||
+		sexa.MustParseTime("1ʰ2ᵐ3ˢ") != unit.NewTime(' ', 1, 2, 3) {
+		t.Fatal("wrong value")
+	}
+	for _, f := range []func(){
+		func() { sexa.MustParseHourAngle("1x") },
+		func() { sexa.MustParseRA("-1ʰ") },
+		func() { sexa.MustParseTime("") },
+	} {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				var pe *sexa.ParseError
+				if !errors.As(err, &pe) {
+					t.Error("want *ParseError, got", err)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,119 @@
+// License: MIT
+
+package sexa
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+)
+
+var (
+	_ fmt.Scanner = (*Angle)(nil)
+	_ fmt.Scanner = (*HourAngle)(nil)
+	_ fmt.Scanner = (*RA)(nil)
+	_ fmt.Scanner = (*Time)(nil)
+)
+
+// scanToken validates the scan verb and reads the next token of non-space
+// characters.
+func scanToken(st fmt.ScanState, verb rune) (string, error) {
+	switch verb {
+	case 'v',
+		secAppend, secCombine, secInsert,
+		minAppend, minCombine, minInsert,
+		hrDegAppend, hrDegCombine, hrDegInsert, compact:
+	default:
+		return "", errors.New("Bad verb '%" + string(verb) + "'")
+	}
+	tok, err := st.Token(true, func(r rune) bool { return !unicode.IsSpace(r) })
+	if err != nil {
+		return "", err
+	}
+	if len(tok) == 0 {
+		return "", io.ErrUnexpectedEOF
+	}
+	return string(tok), nil
+}
+
+// Scan implements fmt.Scanner.
+//
+// Scan reads a token of non-space characters and parses it with the symbols
+// of a.Sym, or Default if a.Sym is nil.  The verbs of the custom formatter
+// are accepted, along with %v.  The parse accepts any of the decimal unit
+// conventions regardless of the verb.  Unit symbols containing spaces cannot
+// be scanned.
+//
+// On success the value is stored in the embedded unit.Angle.  Parsing errors
+// are returned as a *ParseError.
+func (a *Angle) Scan(st fmt.ScanState, verb rune) error {
+	tok, err := scanToken(st, verb)
+	if err != nil {
+		return err
+	}
+	sym := a.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	x, err := sym.ParseAngle(tok)
+	if err != nil {
+		return err
+	}
+	a.Angle = x
+	return nil
+}
+
+// Scan implements fmt.Scanner.  See Angle.Scan.
+func (ha *HourAngle) Scan(st fmt.ScanState, verb rune) error {
+	tok, err := scanToken(st, verb)
+	if err != nil {
+		return err
+	}
+	sym := ha.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	x, err := sym.ParseHourAngle(tok)
+	if err != nil {
+		return err
+	}
+	ha.HourAngle = x
+	return nil
+}
+
+// Scan implements fmt.Scanner.  See Angle.Scan and ParseRA.
+func (ra *RA) Scan(st fmt.ScanState, verb rune) error {
+	tok, err := scanToken(st, verb)
+	if err != nil {
+		return err
+	}
+	sym := ra.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	x, err := sym.ParseRA(tok)
+	if err != nil {
+		return err
+	}
+	ra.RA = x
+	return nil
+}
+
+// Scan implements fmt.Scanner.  See Angle.Scan and Symbols.ParseTime.
+func (t *Time) Scan(st fmt.ScanState, verb rune) error {
+	tok, err := scanToken(st, verb)
+	if err != nil {
+		return err
+	}
+	sym := t.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	x, err := sym.ParseTime(tok)
+	if err != nil {
+		return err
+	}
+	t.Time = x
+	return nil
+}
@@ -0,0 +1,50 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleAngle_Scan() {
+	var a sexa.Angle
+	n, err := fmt.Sscanf("12°34′45″", "%s", &a)
+	fmt.Println(n, err)
+	fmt.Printf("%.4f\n", a.Deg())
+	fmt.Printf("%s\n", &a)
+	// Output:
+	// 1 <nil>
+	// 12.5792
+	// 12°34′45″
+}
+
+func TestScan(t *testing.T) {
+	var ha sexa.HourAngle
+	var ra sexa.RA
+	tm := sexa.Time{Sym: &sexa.Symbols{HMSUnits: sexa.UnitSymbols{":", ":", ""},
+		DecSep: "."}}
+	n, err := fmt.Sscan("-1ʰ2ᵐ3ˢ 1ʰ2ᵐ3ˢ 1:02:03.5", &ha, &ra, &tm)
+	if n != 3 || err != nil {
+		t.Fatal(n, err)
+	}
+	if ha.HourAngle != unit.NewHourAngle('-', 1, 2, 3) ||
+		ra.RA != unit.NewRA(1, 2, 3) ||
+		tm.Time != unit.NewTime(' ', 1, 2, 3.5) {
+		t.Fatal(ha.Hour(), ra.Hour(), tm.Hour())
+	}
+	if _, err = fmt.Sscanf("-1ʰ2ᵐ3ˢ", "%s", &ra); !errors.Is(err, sexa.ErrSign) {
+		t.Fatal(err)
+	}
+	var a sexa.Angle
+	if _, err = fmt.Sscanf("12°", "%x", &a); err == nil {
+		t.Fatal("bad verb accepted")
+	}
+	if _, err = fmt.Sscanf("12x", "%s", &a); !errors.Is(err, sexa.ErrUnitSymbol) {
+		t.Fatal(err)
+	}
+}
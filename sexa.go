@@ -6,8 +6,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"math"
+	"math/big"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/soniakeys/unit"
@@ -23,28 +29,289 @@ var (
 	ErrPosInf          = errors.New("+Inf")
 	ErrNegInf          = errors.New("-Inf")
 	ErrNaN             = errors.New("NaN")
+	ErrOutOfRange      = errors.New("Value out of range")
 )
 
+// ErrBadPrec, ErrBadWidth, and ErrBadVerb report an invalid precision,
+// width, or verb passed to a method such as Angle.Sexagesimal, where a format
+// specifier would output "%!(BADPREC)" or "%!c(BADVERB)" instead.  They are
+// not value errors.
+var (
+	ErrBadPrec  = errors.New("Invalid precision")
+	ErrBadWidth = errors.New("Invalid width")
+	ErrBadVerb  = errors.New("Invalid verb")
+)
+
+// FormatError records a value that could not be formatted.
+//
+// The Err field of a formattable type holds a *FormatError.  It wraps one of
+// the predefined errors, so use errors.Is to test for a particular reason,
+// as in errors.Is(a.Err, ErrDegreeOverflow).
+type FormatError struct {
+	Err   error   // the reason, for example ErrDegreeOverflow
+	Value float64 // the value being formatted, in degrees or hours
+	Prec  int     // the precision requested
+	Width int     // the width requested, or -1 if none
+}
+
+func (e *FormatError) Error() string {
+	if math.IsNaN(e.Value) || math.IsInf(e.Value, 0) {
+		return e.Err.Error() // the value is the reason
+	}
+	s := "Formatting " + strconv.FormatFloat(e.Value, 'g', -1, 64)
+	if e.Prec > 0 {
+		s += " at precision " + strconv.Itoa(e.Prec)
+	}
+	if e.Width >= 0 {
+		s += " in width " + strconv.Itoa(e.Width)
+	}
+	return s + ": " + e.Err.Error()
+}
+
+// Unwrap returns the reason the value could not be formatted.
+func (e *FormatError) Unwrap() error { return e.Err }
+
 // UnitSymbols holds symbols for formatting Angle, HourAngle, RA,
 // and Time types.
 type UnitSymbols struct {
 	HrDeg, Min, Sec string
 }
 
+// UnicodePrimes returns DMS unit symbols with the prime and double prime,
+// U+2032 and U+2033, for minutes and seconds of arc, as in 12°34′56″.
+// These are the units of Default.DMSUnits.
+func UnicodePrimes() UnitSymbols { return UnitSymbols{"°", "′", "″"} }
+
+// AsciiQuotes returns DMS unit symbols limited to ASCII, with the apostrophe
+// and double quote standing in for the primes, as in 12d34'56".
+func AsciiQuotes() UnitSymbols { return UnitSymbols{"d", "'", `"`} }
+
+// mixesPrimes reports whether u pairs a prime with a quote, as in the minute
+// and second symbols ′ and ".
+func (u UnitSymbols) mixesPrimes() bool {
+	return u.Min == "′" && u.Sec == `"` || u.Min == "'" && u.Sec == "″"
+}
+
 // Symbols species unit and decimal indicators
 //
 // Set these as needed, for example to ASCII symbols.
 // It is valid to use multiple character strings for DMSUnits and HMSUnits.
 // It is valid to use empty strings with a fixed width format.
 // DecCombine should be a rune of Unicode category "Mn" (mark, nonspacing).
+// If it is 0, the combining verbs insert the unit as the insert verbs do.
 type Symbols struct {
 	DMSUnits   UnitSymbols
 	HMSUnits   UnitSymbols
 	DecSep     string
 	DecCombine rune
+
+	// MaxIntDigits, if greater than zero, limits the number of integer
+	// digits of decimal hour or degree formats without a specified width.
+	// Values with more integer digits cause overflow.  The default of zero
+	// is unlimited.
+	MaxIntDigits int
+
+	// OverflowRune is repeated to fill the output of a value that cannot
+	// be formatted.  The zero value means '*'.
+	OverflowRune rune
+
+	// OverflowText, if not empty, replaces the fill of OverflowRune as the
+	// output of a value that cannot be formatted.  With a fixed width
+	// format it is padded with spaces or truncated to the width the value
+	// would have had, so that columns still align.  Otherwise it is output
+	// as is.
+	OverflowText string
+
+	// NaNText, PosInfText, and NegInfText, if not empty, replace the
+	// overflow output for NaN, +Inf, and -Inf values.  As with OverflowText
+	// they are fit to a fixed width.  The Err field is still set.
+	NaNText, PosInfText, NegInfText string
+
+	// PadRune pads the leading segment to a specified width, as with dot
+	// leaders or figure spaces.  It replaces the spaces otherwise used
+	// ahead of the segment, or after the result with the '-' flag, but not
+	// the zeros of the '0' flag, SignPad, or spaces within the result.
+	// The sign column precedes the padding in all formats, as in " ..3.07°",
+	// so that leaders run up to the digits.  The zero value means ' '.
+	PadRune rune
+
+	// DoubleUnit, if true, repeats the unit at the end of the decimal
+	// segment for the combined and inserted decimal unit conventions,
+	// as in 12°34′45″̣6″.
+	DoubleUnit bool
+
+	// PosSign, NegSign, and SignPad are sign indicators.  NegSign is used
+	// for negative values and PosSign for non-negative values with the '+'
+	// flag, for example "−", U+2212 MINUS SIGN, for typeset output.
+	// SignPad holds the place of an elided plus sign with the ' ' flag or
+	// with a fixed width.  It should have the same display width as the
+	// other signs.  The zero values mean "+", "-", and for SignPad, as many
+	// spaces as the wider of PosSign and NegSign has characters.
+	PosSign, NegSign, SignPad string
+
+	// NegParens, if true, encloses negative values in NegParenOpen and
+	// NegParenClose in place of NegSign, as in (12°34′45″).  The opening
+	// parenthesis takes the place of the sign.  With the ' ' flag or a fixed
+	// width, non-negative values are followed by SignPad in place of the
+	// closing parenthesis, so that values align.  Hemisphere indicators
+	// take precedence over NegParens.  The zero values mean "(" and ")".
+	NegParens                   bool
+	NegParenOpen, NegParenClose string
+
+	// TrailingSign, if true, places the sign indicator after the value
+	// rather than before it, as in 12°34′45″-.  The sign column of the ' '
+	// flag or a fixed width moves to the right edge with it, ahead of any
+	// padding of the '-' flag.  Hemisphere indicators and NegParens take
+	// precedence over TrailingSign.
+	TrailingSign bool
+
+	// RangeSep separates the values formatted by FormatRange.  The zero
+	// value means " – ", an en dash surrounded by spaces.
+	RangeSep string
+
+	// TrimTrailingZeros, if true, removes trailing zeros from the decimal
+	// segment, and the decimal separator if no decimal places remain.
+	// The precision is then the maximum number of decimal places.
+	// The '#' flag keeps the zeros.
+	TrimTrailingZeros bool
+
+	// HighPrecision, if true, allows precisions above 15, up to 40, for
+	// values small enough to have the significance.  The digits are those
+	// of the float64 value, computed exactly with math/big.  Values too
+	// large for the precision overflow with ErrLossOfPrecision, as with
+	// lower precisions.
+	HighPrecision bool
+
+	// Rounding selects how values are rounded to the formatted precision.
+	// The zero value is RoundHalfUp.
+	Rounding RoundingMode
+
+	// TotalWidth, if true, changes the meaning of a format width to the
+	// total width of the result, counted in characters not including
+	// combining marks.  Results are padded with spaces on the left, or on
+	// the right with the '-' flag.  A result that does not fit the width
+	// overflows.
+	TotalWidth bool
+
+	// SigFigs, if greater than 0, is a number of significant digits that
+	// sets the precision when none is specified, as 12.6° or 0.0126° for
+	// 3 with %h.  Digits are counted from the first non-zero digit, with
+	// two for each sexagesimal segment that follows, as 1°02′03.4″ has 6.
+	// The precision is the count less the integer digits, within 0 to 15,
+	// with a zero value taken to have one integer digit.  The count is of the
+	// value before rounding, so a value rounding up to another digit, as
+	// 9.996° to 10.0°, can show one more.  An explicit precision takes
+	// precedence over SigFigs.
+	SigFigs int
+
+	// ElideZeroSeconds, if true, omits a seconds segment of zero from full
+	// sexagesimal formats, as in 12°34′ rather than 12°34′0″.  This is
+	// independent of the elision of leading zero segments.  It applies only
+	// at precision 0 and without a width, and a value of zero still formats
+	// with a seconds segment, as 0″.
+	ElideZeroSeconds bool
+
+	// PadSexagesimal, if true, pads minutes and seconds, and the hours
+	// following a days segment, to two digits, as in 12°04′05″, as the '0'
+	// flag does, but without the '0' flag's padding of the first segment
+	// to a width.
+	PadSexagesimal bool
+
+	// GroupSep, if not empty, separates groups of three digits in the
+	// integer part of the first segment, as in 1,296,000″.  A width still
+	// counts digits, and the field is padded to the grouped width of that
+	// many digits.
+	GroupSep string
+
+	// RevUnit is the unit symbol of the %t format of turns, or revolutions,
+	// as " rev" or " cyc".  The zero value means no unit.
+	RevUnit string
+
+	// SegSep separates the segments of the %u format, in place of unit
+	// symbols.  The zero value means " ".
+	SegSep string
+
+	// DayUnit, if not empty, is the unit symbol of a days segment for Time.
+	// The sexagesimal formats of a Time of a day or more then start with
+	// whole days, as in 2ᵈ2ʰ0ᵐ0ˢ.  With a fixed width, the width applies to
+	// the days segment.  The decimal hour formats are not affected.
+	DayUnit string
+
+	// NorthSouth holds the hemisphere indicators of Latitude, for north
+	// and south.  The zero value means "N" and "S".
+	NorthSouth [2]string
+
+	// EastWest holds the hemisphere indicators of Longitude, for east and
+	// west.  The zero value means "E" and "W".
+	EastWest [2]string
+
+	// CoordSep separates the right ascension and declination formatted by
+	// EqCoord.  The zero value means " ".
+	CoordSep string
+
+	// JSONNumeric, if true, makes MarshalJSON encode the embedded value
+	// as a JSON number rather than as a formatted string.  The number is
+	// radians for Angle, HourAngle, and RA, and seconds for Time.
+	JSONNumeric bool
 }
 
-// Default symbols are used by package top-level functions.
+// RoundingMode selects a method of rounding to a formatted precision.
+//
+// Rounding applies to the magnitude of a value, so for example RoundHalfUp
+// rounds half-way negative values away from zero, and RoundTowardZero
+// truncates negative values toward zero.
+//
+// RoundTowardZero, as some standards require for coordinates, formats
+// 45.678″ at precision 1 as 45.6″.  A value that falls a few ulps short of a
+// digit through the conversion of units, as 45.7″ can, is taken as that
+// digit rather than truncated to the one before.
+type RoundingMode int
+
+// Rounding modes for Symbols.Rounding.
+const (
+	RoundHalfUp     RoundingMode = iota // round half-way values up
+	RoundHalfEven                       // round half-way values to even
+	RoundTowardZero                     // truncate
+)
+
+// overflowRune returns sym.OverflowRune, or the default '*'.
+func (sym *Symbols) overflowRune() rune {
+	if sym.OverflowRune == 0 {
+		return '*'
+	}
+	return sym.OverflowRune
+}
+
+// IsOverflowOutput reports whether s is the output of a custom formatter
+// for a value that could not be formatted, that is, a non-empty string
+// consisting of only the overflow rune of sym, or if sym.OverflowText is set,
+// that text as output for a fixed width.
+//
+// If sym is nil, package variable Default is used.
+func IsOverflowOutput(s string, sym *Symbols) bool {
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	if sym.OverflowText > "" {
+		t := strings.Trim(s, " ")
+		return t > "" && strings.HasPrefix(sym.OverflowText, t)
+	}
+	o := sym.overflowRune()
+	for _, r := range s {
+		if r != o {
+			return false
+		}
+	}
+	return s > ""
+}
+
+// Default symbols are used by package top-level functions, and by
+// formattable values with a nil Sym.
+//
+// Treat Default as read-only.  Modifying it while other goroutines format or
+// parse is a data race.  To change the default symbols, use
+// SetDefaultSymbols.  To derive modified symbols, start with a copy from
+// DefaultSymbols or Symbols.Clone.
 var Default = &Symbols{
 	DMSUnits:   UnitSymbols{"°", "′", "″"},
 	HMSUnits:   UnitSymbols{"ʰ", "ᵐ", "ˢ"},
@@ -52,17 +319,95 @@ var Default = &Symbols{
 	DecCombine: '\u0323',
 }
 
+// defaultMu guards the variable Default, though not the Symbols it points to.
+var defaultMu sync.RWMutex
+
+// SetDefaultSymbols replaces the default symbols with sym.
+//
+// It is safe to call concurrently with formatting and parsing.  Sym should
+// not be modified afterward.
+func SetDefaultSymbols(sym *Symbols) {
+	defaultMu.Lock()
+	Default = sym
+	defaultMu.Unlock()
+}
+
+// DefaultSymbols returns a copy of the default symbols.
+//
+// The copy can be modified freely, and passed to SetDefaultSymbols.
+func DefaultSymbols() *Symbols { return defaultSymbols().Clone() }
+
+// defaultSymbols returns Default, read under defaultMu.
+func defaultSymbols() *Symbols {
+	defaultMu.RLock()
+	d := Default
+	defaultMu.RUnlock()
+	return d
+}
+
+// Clone returns a copy of sym.
+func (sym *Symbols) Clone() *Symbols {
+	c := *sym
+	return &c
+}
+
+// ASCII symbols are predefined for output limited to ASCII.
+//
+// DecCombine is 0, so the combining verbs fall back to inserting the unit
+// before the decimal separator, as the insert verbs do.  ASCII is used by
+// the constructors FmtAngleASCII, FmtHourAngleASCII, FmtRAASCII, and
+// FmtTimeASCII.  As with Default, treat ASCII as read-only and derive
+// modified symbols with Clone.
+var ASCII = &Symbols{
+	DMSUnits: UnitSymbols{"d", "m", "s"},
+	HMSUnits: UnitSymbols{"h", "m", "s"},
+	DecSep:   ".",
+}
+
+// NewSymbols returns Symbols with the given units and decimal symbols,
+// after checking that they can work together.
+//
+// DecCombine, if nonzero, must be a Unicode nonspacing mark (category Mn) so
+// that it combines with the decimal unit, and then decSep must be nonempty
+// to identify the decimal point to be combined.
+//
+// The minute and second symbols of dms must not mix a Unicode prime with an
+// ASCII quote, as ′ with ".  See UnicodePrimes and AsciiQuotes.  To mix them
+// intentionally, construct the Symbols directly.
+func NewSymbols(dms, hms UnitSymbols, decSep string, decCombine rune) (*Symbols, error) {
+	if dms.mixesPrimes() {
+		return nil, fmt.Errorf("DMSUnits %q and %q mix primes and quotes",
+			dms.Min, dms.Sec)
+	}
+	if decCombine != 0 {
+		if !unicode.Is(unicode.Mn, decCombine) {
+			return nil, fmt.Errorf("DecCombine %U is not a nonspacing mark",
+				decCombine)
+		}
+		if decSep == "" {
+			return nil, errors.New("DecCombine requires a nonempty DecSep")
+		}
+	}
+	return &Symbols{
+		DMSUnits:   dms,
+		HMSUnits:   hms,
+		DecSep:     decSep,
+		DecCombine: decCombine,
+	}, nil
+}
+
 // CombineUnit inserts a unit indicator into a formatted decimal number,
 // combining it if possible with the decimal separator.
 //
 // The decimal separator is identified by the package variable Default.
 // If Default.DecSep is non-empty and occurrs in d, the occurrence is replaced
 // with argument 'unit' and the symbol Default.DecCombine.  Otherwise unit is
-// appended to the end of d.
+// appended to the end of d.  If Default.DecCombine is 0, unit is inserted
+// as by InsertUnit.
 //
 // See also InsertUnit, StripUnit, and Symbols.CombineUnit.
 func CombineUnit(d, unit string) string {
-	return Default.CombineUnit(d, unit)
+	return defaultSymbols().CombineUnit(d, unit)
 }
 
 // InsertUnit inserts a unit indicator into a formatted decimal number.
@@ -76,7 +421,7 @@ func CombineUnit(d, unit string) string {
 //
 // See also CombineUnit, StripUnit, and Symbols.InsertUnit.
 func InsertUnit(d, unit string) string {
-	return Default.InsertUnit(d, unit)
+	return defaultSymbols().InsertUnit(d, unit)
 }
 
 // StripUnit reverses the action of InsertUnit or CombineUnit,
@@ -93,7 +438,7 @@ func InsertUnit(d, unit string) string {
 // StripUnit returns ok = true if the unit was found and removed.  Otherwise it
 // returns d unchanged and ok = false.
 func StripUnit(d, unit string) (stripped string, ok bool) {
-	return Default.StripUnit(d, unit)
+	return defaultSymbols().StripUnit(d, unit)
 }
 
 // Angle is represents a formattable angle.
@@ -106,21 +451,178 @@ type Angle struct {
 // FmtAngle constructs an formattable Angle containing the value a.
 func FmtAngle(a unit.Angle) *Angle { return &Angle{Angle: a} }
 
+// FmtAngleASCII constructs a formattable Angle containing the value a
+// and using ASCII symbols "d", "m", and "s".
+func FmtAngleASCII(a unit.Angle) *Angle { return ASCII.FmtAngle(a) }
+
 // Format implements fmt.Formatter
 func (a *Angle) Format(f fmt.State, c rune) {
 	s := state{
-		State:  f,
 		verb:   c,
 		hrDeg:  a.Deg(),
 		caller: fsAngle,
 		sym:    a.Sym,
 	}
+	s.fromFmt(f)
 	a.Err = s.writeFormatted()
 }
 
 // String implements fmt.Stringer
 func (a *Angle) String() string { return fmt.Sprintf("%s", a) }
 
+// StringPrec formats a as String does, with precision prec.
+//
+// It does not go through a Printf function of package fmt, so an invalid
+// precision gives "%!(BADPREC prec)" alone, as with AppendFormat.
+func (a *Angle) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	a.Format(f, 's')
+	return string(f.buf)
+}
+
+// FormattedWidth returns the width of a formatted with the verb and
+// precision prec, without a width or flags, and the value error formatting
+// would set in Err.
+//
+// The width is counted in characters not including a combining DecCombine
+// mark, as is the width of overflow output.  A value error gives the width
+// of the overflow output.  An invalid verb or precision gives 0 and a
+// *FormatError wrapping ErrBadVerb or ErrBadPrec.
+func (a *Angle) FormattedWidth(verb rune, prec int) (int, error) {
+	sym := a.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	s := state{verb: verb, hrDeg: a.Deg(), prec: prec, precOK: true, sym: sym}
+	if err := s.checkFormat(); err != nil {
+		return 0, err
+	}
+	b, err := appendFormatted(nil, a.Deg(), fsAngle, verb, prec, sym)
+	return sym.displayWidth(b), err
+}
+
+// Overflows reports whether a would overflow if formatted with the verb,
+// precision prec, and width, returning the value error formatting would set
+// in Err, such as a *FormatError wrapping ErrDegreeOverflow or
+// ErrLossOfPrecision.  A width of 0 or less means no width.
+//
+// An invalid verb or precision gives false and a *FormatError wrapping
+// ErrBadVerb or ErrBadPrec.  Nothing is written and Err is not modified.
+func (a *Angle) Overflows(verb rune, prec, width int) (bool, error) {
+	s := state{
+		w:       io.Discard,
+		verb:    verb,
+		hrDeg:   a.Deg(),
+		width:   width,
+		widthOK: width > 0,
+		prec:    prec,
+		precOK:  true,
+		caller:  fsAngle,
+		sym:     a.Sym,
+	}
+	if err := s.checkFormat(); err != nil {
+		return false, err
+	}
+	err := s.writeFormatted()
+	return err != nil, err
+}
+
+// EqualAt reports whether a and b format identically with the verb and
+// precision prec, using the symbols of a.
+//
+// The comparison is of the formatted results, so it agrees with the rounding
+// of the formatter.  Values that cannot be formatted, such as NaN or values
+// that lose precision, are not equal to anything, nor are any values with
+// an invalid verb or precision.
+func (a *Angle) EqualAt(b unit.Angle, prec int, verb rune) bool {
+	sym := a.Sym
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	if !validVerb(verb) || verb != integerKey && !sym.validPrec(prec) {
+		return false
+	}
+	var bufA, bufB [64]byte
+	fa, err := appendFormatted(bufA[:0], a.Deg(), fsAngle, verb, prec, sym)
+	if err != nil {
+		return false
+	}
+	fb, err := appendFormatted(bufB[:0], b.Deg(), fsAngle, verb, prec, sym)
+	return err == nil && bytes.Equal(fa, fb)
+}
+
+// RoundToSec returns the value of a rounded to a whole arc second.
+//
+// Rounding is by the mode of the symbols of a, so that the result agrees
+// with the value as formatted in seconds at precision 0.
+func (a *Angle) RoundToSec() unit.Angle {
+	return unit.AngleFromDeg(roundScaled(a.Deg(), 3600, a.rounding()))
+}
+
+// RoundToMin returns the value of a rounded to a whole arc minute.
+// See RoundToSec.
+func (a *Angle) RoundToMin() unit.Angle {
+	return unit.AngleFromDeg(roundScaled(a.Deg(), 60, a.rounding()))
+}
+
+// RoundTo returns the value of a rounded to a multiple of q, for example
+// unit.AngleFromSec(.1) for the nearest tenth of an arc second.
+// See RoundToSec.  If q is zero, the value is returned unchanged.
+func (a *Angle) RoundTo(q unit.Angle) unit.Angle {
+	if q == 0 {
+		return a.Angle
+	}
+	return q.Mul(roundScaled(float64(a.Angle/q), 1, a.rounding()))
+}
+
+// WrapTo360 returns the value of a wrapped to the range [0°, 360°).
+//
+// Unlike the normalization RA applies as it formats, the wrapped value is
+// returned for the caller to keep or format, as with
+// sexa.FmtAngle(a.WrapTo360()).  The receiver is not modified.
+func (a *Angle) WrapTo360() unit.Angle {
+	return unit.Angle(wrap(a.Rad(), 2*math.Pi))
+}
+
+// WrapTo180 returns the value of a wrapped to the range [-180°, 180°).
+// See WrapTo360.
+func (a *Angle) WrapTo180() unit.Angle {
+	return unit.Angle(wrap(a.Rad()+math.Pi, 2*math.Pi) - math.Pi)
+}
+
+// DecimalDeg returns a as plain decimal degrees with prec decimal places, as
+// "-122.4194", for GeoJSON and similar formats that want just the number.
+//
+// The result has no unit symbol regardless of the symbols of a.  The decimal
+// separator is ".", and the sign of a negative value is "-".  Of the symbols
+// of a, only Rounding and HighPrecision apply.  Err is set as for formatting,
+// and a value that cannot be formatted gives asterisks.
+//
+// Prec must be 0 to 15, or to 40 with HighPrecision.  Otherwise the result
+// is empty and Err wraps ErrBadPrec.
+func (a *Angle) DecimalDeg(prec int) string {
+	b, err := appendPlainDecimal(nil, a.Deg(), fsAngle, prec, a.Sym)
+	a.Err = err
+	return string(b)
+}
+
+// wrap returns x modulo y in the range [0, y), for y > 0.  Unlike unit.PMod
+// it does not return y for small negative x.
+func wrap(x, y float64) float64 {
+	if r := unit.PMod(x, y); r < y {
+		return r
+	}
+	return 0
+}
+
+// rounding returns the rounding mode of the symbols of a.
+func (a *Angle) rounding() RoundingMode {
+	if a.Sym == nil {
+		return defaultSymbols().Rounding
+	}
+	return a.Sym.Rounding
+}
+
 // HourAngle represents a formattable angle hour.
 type HourAngle struct {
 	unit.HourAngle
@@ -133,21 +635,42 @@ func FmtHourAngle(h unit.HourAngle) *HourAngle {
 	return &HourAngle{HourAngle: h}
 }
 
+// FmtHourAngleASCII constructs a formattable HourAngle containing the
+// value h and using ASCII symbols "h", "m", and "s".
+func FmtHourAngleASCII(h unit.HourAngle) *HourAngle {
+	return ASCII.FmtHourAngle(h)
+}
+
 // Format implements fmt.Formatter
 func (ha *HourAngle) Format(f fmt.State, c rune) {
 	s := &state{
-		State:  f,
 		verb:   c,
 		hrDeg:  ha.Hour(),
 		caller: fsHourAngle,
 		sym:    ha.Sym,
 	}
+	s.fromFmt(f)
 	ha.Err = s.writeFormatted()
 }
 
 // String implements fmt.Stringer
 func (ha *HourAngle) String() string { return fmt.Sprintf("%s", ha) }
 
+// StringPrec formats ha as String does, with precision prec.
+func (ha *HourAngle) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	ha.Format(f, 's')
+	return string(f.buf)
+}
+
+// DecimalHour returns ha as plain decimal hours with prec decimal places, as
+// "-1.5000".  See Angle.DecimalDeg.
+func (ha *HourAngle) DecimalHour(prec int) string {
+	b, err := appendPlainDecimal(nil, ha.Hour(), fsHourAngle, prec, ha.Sym)
+	ha.Err = err
+	return string(b)
+}
+
 // RA represents a formattable right ascension.
 type RA struct {
 	unit.RA
@@ -158,22 +681,41 @@ type RA struct {
 // FmtRA constructs an formattable RA containing the value ra.
 func FmtRA(ra unit.RA) *RA { return &RA{RA: ra} }
 
+// FmtRAASCII constructs a formattable RA containing the value ra
+// and using ASCII symbols "h", "m", and "s".
+func FmtRAASCII(ra unit.RA) *RA { return ASCII.FmtRA(ra) }
+
 // Format implements fmt.Formatter, formatting to hours, minutes, and seconds.
 func (ra *RA) Format(f fmt.State, c rune) {
 	s := &state{
-		State: f,
-		verb:  c,
+		verb: c,
 		// pmod in case ra.RA was directly set to something out of range
 		hrDeg:  unit.PMod(ra.Hour(), 24),
 		caller: fsRA,
 		sym:    ra.Sym,
 	}
+	s.fromFmt(f)
 	ra.Err = s.writeFormatted()
 }
 
 // String implements fmt.Stringer
 func (ra *RA) String() string { return fmt.Sprintf("%s", ra) }
 
+// StringPrec formats ra as String does, with precision prec.
+func (ra *RA) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	ra.Format(f, 's')
+	return string(f.buf)
+}
+
+// DecimalHour returns ra as plain decimal hours with prec decimal places, in
+// the range [0, 24).  See Angle.DecimalDeg.
+func (ra *RA) DecimalHour(prec int) string {
+	b, err := appendPlainDecimal(nil, unit.PMod(ra.Hour(), 24), fsRA, prec, ra.Sym)
+	ra.Err = err
+	return string(b)
+}
+
 // Time represents a formattable duration or relative time.
 type Time struct {
 	unit.Time
@@ -184,21 +726,127 @@ type Time struct {
 // FmtTime constructs an formattable Time containing the value t.
 func FmtTime(t unit.Time) *Time { return &Time{Time: t} }
 
+// FmtTimeASCII constructs a formattable Time containing the value t
+// and using ASCII symbols "h", "m", and "s".
+func FmtTimeASCII(t unit.Time) *Time { return ASCII.FmtTime(t) }
+
 // Format implements fmt.Formatter, formatting to hours, minutes, and seconds.
 func (t *Time) Format(f fmt.State, c rune) {
 	s := &state{
-		State:  f,
 		verb:   c,
 		hrDeg:  t.Hour(),
 		caller: fsTime,
 		sym:    t.Sym,
 	}
+	s.fromFmt(f)
 	t.Err = s.writeFormatted()
 }
 
 // String implements fmt.Stringer
 func (t *Time) String() string { return fmt.Sprintf("%s", t) }
 
+// StringPrec formats t as String does, with precision prec.
+func (t *Time) StringPrec(prec int) string {
+	f := &fmtState{prec: prec}
+	t.Format(f, 's')
+	return string(f.buf)
+}
+
+// DecimalHour returns t as plain decimal hours with prec decimal places.
+// See Angle.DecimalDeg.
+func (t *Time) DecimalHour(prec int) string {
+	b, err := appendPlainDecimal(nil, t.Hour(), fsTime, prec, t.Sym)
+	t.Err = err
+	return string(b)
+}
+
+// FmtDuration constructs a formattable Time containing the duration d.
+//
+// The conversion from integer nanoseconds to float64 seconds is lossy.
+// Durations under 2²² seconds, about 48 days, convert back with Time.Duration
+// to the same nanosecond.  Longer durations may not.
+func FmtDuration(d time.Duration) *Time { return &Time{Time: unit.Time(d.Seconds())} }
+
+// Duration returns t as a time.Duration, rounded to the nearest nanosecond.
+//
+// Values beyond the range of time.Duration, about ±292 years, saturate to the
+// largest positive or negative duration.  NaN returns 0.
+func (t *Time) Duration() time.Duration {
+	ns := math.Round(t.Sec() * 1e9)
+	switch {
+	case math.IsNaN(ns):
+		return 0
+	case ns >= 1<<63:
+		return math.MaxInt64
+	case ns <= -1<<63:
+		return math.MinInt64
+	}
+	return time.Duration(ns)
+}
+
+// RoundToMin returns the value of t rounded to a whole minute, carrying into
+// hours, as 11ʰ59ᵐ45ˢ to 12ʰ0ᵐ0ˢ.
+//
+// Rounding is of the magnitude, by the mode of the symbols of t, so that the
+// result agrees with the value as formatted in minutes at precision 0.  A
+// result of a day or more formats with a days segment if Symbols.DayUnit is
+// set.
+func (t *Time) RoundToMin() unit.Time {
+	return unit.Time(math.Round(roundScaled(t.Hour(), 60, t.rounding()) * 3600))
+}
+
+// RoundToHour returns the value of t rounded to a whole hour.
+// See RoundToMin.
+func (t *Time) RoundToHour() unit.Time {
+	return unit.Time(math.Round(roundScaled(t.Hour(), 1, t.rounding()) * 3600))
+}
+
+// rounding returns the rounding mode of the symbols of t.
+func (t *Time) rounding() RoundingMode {
+	if t.Sym == nil {
+		return defaultSymbols().Rounding
+	}
+	return t.Sym.Rounding
+}
+
+// SexaFormatter is implemented by the formattable types Angle, HourAngle, RA,
+// and Time, and by the coordinate types such as Latitude and Dec.
+type SexaFormatter interface {
+	fmt.Formatter
+	fmt.Stringer
+}
+
+var (
+	_ SexaFormatter = (*Angle)(nil)
+	_ SexaFormatter = (*HourAngle)(nil)
+	_ SexaFormatter = (*RA)(nil)
+	_ SexaFormatter = (*Time)(nil)
+	_ SexaFormatter = (*Latitude)(nil)
+	_ SexaFormatter = (*Longitude)(nil)
+	_ SexaFormatter = (*Dec)(nil)
+	_ SexaFormatter = (*EqCoord)(nil)
+	_ SexaFormatter = (*Clock)(nil)
+)
+
+// Fmt constructs a formattable value for any of the types unit.Angle,
+// unit.HourAngle, unit.RA, or unit.Time.
+//
+// The result is an *Angle, *HourAngle, *RA, or *Time.  For a value of any
+// other type, Fmt returns an error.
+func Fmt(v interface{}) (fmt.Formatter, error) {
+	switch v := v.(type) {
+	case unit.Angle:
+		return FmtAngle(v), nil
+	case unit.HourAngle:
+		return FmtHourAngle(v), nil
+	case unit.RA:
+		return FmtRA(v), nil
+	case unit.Time:
+		return FmtTime(v), nil
+	}
+	return nil, fmt.Errorf("Unsupported type %T", v)
+}
+
 // FmtAngle constructs an formattable Angle containing the value a.
 func (sym *Symbols) FmtAngle(a unit.Angle) *Angle { return &Angle{a, sym, nil} }
 
@@ -218,17 +866,21 @@ func (sym *Symbols) FmtTime(t unit.Time) *Time {
 // CombineUnit inserts a unit indicator into a formatted decimal number,
 // combining it if possible with the decimal separator.
 //
-// If sym.DecSep is non-empty and occurrs in d, the occurrence is replaced with
-// argument 'unit' and the symbol sym.DecCombine.  Otherwise unit is appended
-// to the end of d.
+// If sym.DecSep is non-empty and occurrs in d, the last occurrence is replaced
+// with argument 'unit' and the symbol sym.DecCombine.  Otherwise unit is
+// appended to the end of d.  If sym.DecCombine is 0, unit is inserted as by
+// InsertUnit, so that symbols limited to ASCII still show the unit.
 //
 // See also InsertUnit, StripUnit, and the corresponding top-level functions
 // that use package default symbols.
 func (sym *Symbols) CombineUnit(d, unit string) string {
-	if sym.DecSep == "" || sym.DecCombine == 0 {
+	if sym.DecCombine == 0 {
+		return sym.InsertUnit(d, unit) // fall back to inserting
+	}
+	if sym.DecSep == "" {
 		return d + unit // DecSep empty, append unit
 	}
-	i := strings.Index(d, sym.DecSep)
+	i := strings.LastIndex(d, sym.DecSep)
 	if i < 0 {
 		return d + unit // no DecSep found, append unit
 	}
@@ -242,7 +894,8 @@ func (sym *Symbols) CombineUnit(d, unit string) string {
 // present, or at the end of the number otherwise.
 //
 // If sym.DecSep is non-empty and occurrs in d, unit is added just before the
-// occurrence.  Otherwise unit is appended to the end of d.
+// last occurrence, as an earlier one may be a digit group separator.
+// Otherwise unit is appended to the end of d.
 //
 // See also CombineUnit, StripUnit, and the corresponding top-level functions
 // that use package default symbols.
@@ -250,7 +903,7 @@ func (sym *Symbols) InsertUnit(d, unit string) string {
 	if sym.DecSep == "" {
 		return d + unit // DecSep empty, append unit
 	}
-	i := strings.Index(d, sym.DecSep)
+	i := strings.LastIndex(d, sym.DecSep)
 	if i < 0 {
 		return d + unit // no DecSep found, append unit
 	}
@@ -266,27 +919,44 @@ func (sym *Symbols) InsertUnit(d, unit string) string {
 // or if sym.DecSep is non-empty and ocurrs immediately following, then the unit
 // is Removed.  If the specified unit is found with sym.DecCombine immediately
 // following, then both the unit and the DecCombine rune are replaced with
-// sym.DecSep.
+// sym.DecSep.  If sym.DoubleUnit is set, a unit repeated at the end of d
+// is removed as well.
 //
 // StripUnit returns ok = true if the unit was found and removed.  Otherwise it
 // returns d unchanged and ok = false.
 func (sym *Symbols) StripUnit(d, unit string) (stripped string, ok bool) {
-	xu := strings.Index(d, unit)
-	if xu < 0 {
-		return d, false
-	}
-	xd := xu + len(unit)
-	if xd == len(d) {
-		return d[:xu], true // string ends with unit.  just remove the unit.
+	if t := strings.TrimSuffix(d, unit); sym.DoubleUnit && t != d {
+		// try removing a repeated unit first
+		if stripped, ok = sym.stripUnit(t, unit); ok {
+			return
+		}
 	}
-	if sym.DecSep != "" && strings.HasPrefix(d[xd:], sym.DecSep) {
-		return d[:xu] + d[xd:], true // remove unit, retain DecSep
+	return sym.stripUnit(d, unit)
+}
+
+func (sym *Symbols) stripUnit(d, unit string) (stripped string, ok bool) {
+	if unit == "" {
+		return d, false
 	}
-	if r, sz := utf8.DecodeRuneInString(d[xd:]); r == sym.DecCombine {
-		// replace unit and DecCombine with DecSep
-		return d[:xu] + sym.DecSep + d[xd+sz:], true
+	// search from the end, as the unit may also match inside the number.
+	for end := len(d); ; {
+		xu := strings.LastIndex(d[:end], unit)
+		if xu < 0 {
+			return d, false // otherwise don't mess with it
+		}
+		xd := xu + len(unit)
+		if xd == len(d) {
+			return d[:xu], true // string ends with unit.  just remove the unit.
+		}
+		if sym.DecSep != "" && strings.HasPrefix(d[xd:], sym.DecSep) {
+			return d[:xu] + d[xd:], true // remove unit, retain DecSep
+		}
+		if r, sz := utf8.DecodeRuneInString(d[xd:]); r == sym.DecCombine {
+			// replace unit and DecCombine with DecSep
+			return d[:xu] + sym.DecSep + d[xd+sz:], true
+		}
+		end = xd - 1
 	}
-	return d, false // otherwise don't mess with it
 }
 
 const (
@@ -299,6 +969,13 @@ const (
 	hrDegAppend  = 'h'
 	hrDegCombine = 'i'
 	hrDegInsert  = 'j'
+	compact      = 'g'
+	totalSec     = 'S'
+	totalMin     = 'M'
+	unitless     = 'u'
+	withDecimal  = 'r'
+	integerKey   = 'k'
+	turns        = 't'
 )
 
 const (
@@ -308,19 +985,91 @@ const (
 	fsTime
 )
 
+// flagSet holds the flags of a format specifier.
+type flagSet uint8
+
+const (
+	flagPlus flagSet = 1 << iota
+	flagSpace
+	flagSharp
+	flagZero
+	flagMinus
+)
+
+// segment formatting methods, as selected by the verb
+const (
+	formSec = iota
+	formMin
+	formHrDeg
+	formCompact
+	formTotalSec
+	formTotalMin
+	formTurns
+)
+
+// state is the state of formatting a single value.
+//
+// The output, width, precision, and flags are held independently of
+// fmt.State, so that the formatting methods can be driven either by a custom
+// formatter, with fromFmt, or directly.
 type state struct {
-	fmt.State         // 'f' in fmt.Formatter doc.  kind of handy to embed this.
-	verb      rune    // 'c' in fmt.Formatter doc
-	hrDeg     float64 // input, value to format
-	prec      int     // f.Precision with a default of 0
-	caller    int     // use fs constants
-	sym       *Symbols
-	units     UnitSymbols
+	w       io.Writer // output
+	verb    rune      // 'c' in fmt.Formatter doc
+	hrDeg   float64   // input, value to format
+	width   int
+	widthOK bool
+	prec    int  // precision, validated with a default of 0
+	precOK  bool // prec was specified
+	flags   flagSet
+	caller  int // use fs constants
+	sym     *Symbols
+	units   UnitSymbols
+	form    int // segment formatting method, use form constants
+	trail   int // spaces following the result, with the '-' flag
+
+	// hemisphere indicators for positive and negative values.  if set,
+	// these follow the value in place of a leading sign.
+	hemi  [2]string
+	neg   bool    // sign of the formatted value, for hemi
+	limit float64 // maximum magnitude of hrDeg, if > 0
+
+	noSignPad bool   // a width does not imply the ' ' flag
+	postSign  string // sign following the result, with Symbols.TrailingSign
+	sharpSep  bool   // '#' forces a decimal separator at precision 0
+
+	// a precision above 15 is formatted as precision 0 plus bigPrec
+	// further decimal places, computed by round and left in frac.
+	bigPrec int
+	frac    []byte
 }
 
+// fromFmt sets the output, width, precision, and flags of s from f.
+func (s *state) fromFmt(f fmt.State) {
+	s.w = f
+	s.width, s.widthOK = f.Width()
+	s.prec, s.precOK = f.Precision()
+	s.flags = 0
+	for i, c := range "+ #0-" {
+		if f.Flag(int(c)) {
+			s.flags |= 1 << i
+		}
+	}
+}
+
+// writeFormatted formats s.hrDeg and writes the result to s.w, returning any
+// value error.
 func (s *state) writeFormatted() error {
+	var buf [64]byte
+	b, err := s.appendFormat(buf[:0])
+	s.w.Write(b)
+	return err
+}
+
+// appendFormat formats s.hrDeg as writeFormatted does, appending the result
+// to b.
+func (s *state) appendFormat(b []byte) ([]byte, error) {
 	if s.sym == nil {
-		s.sym = Default
+		s.sym = defaultSymbols()
 	}
 	switch {
 	case s.caller == fsAngle:
@@ -330,44 +1079,92 @@ func (s *state) writeFormatted() error {
 	}
 
 	// valiate verb, pick formatting method in the process
-	var f func() (string, error)
 	switch s.verb {
 	case 'v':
 		fallthrough
 	case secAppend, secCombine, secInsert:
-		f = s.decimalSec // it's a method value! see the spec.
+		s.form = formSec
 	case minAppend, minCombine, minInsert:
-		f = s.decimalMin
+		s.form = formMin
 	case hrDegAppend, hrDegCombine, hrDegInsert:
-		f = s.decimalHrDeg
+		s.form = formHrDeg
+	case compact:
+		s.form = formCompact
+	case totalSec:
+		s.form = formTotalSec
+	case totalMin:
+		s.form = formTotalMin
+	case unitless:
+		// all segments, separated rather than followed by units
+		sep := s.sym.SegSep
+		if sep == "" {
+			sep = " "
+		}
+		s.units = UnitSymbols{sep, sep, ""}
+		s.flags |= flagSharp
+		s.form = formSec
+	case withDecimal:
+		s.form = formSec
+	case turns:
+		s.form = formTurns
+	case integerKey:
+		// whole hours or degrees, zero padded, with no unit or sign pad
+		s.units = UnitSymbols{}
+		s.flags |= flagZero
+		s.noSignPad = true
+		s.prec, s.precOK = 0, false
+		s.form = formHrDeg
 	default:
-		fmt.Fprintf(s, "%%!%c(BADVERB)", s.verb)
-		return nil // not a value error
+		b = append(b, "%!"...)
+		b = utf8.AppendRune(b, s.verb)
+		return append(b, "(BADVERB)"...), nil // not a value error
+	}
+
+	if !s.precOK && s.sym.SigFigs > 0 {
+		s.prec, s.precOK = s.sigFigsPrec(s.sym.SigFigs), true
 	}
 
 	// validate precision, storing it in the receiver.
 	// 0 is our default if it's not specified.
 	// (the docs don't define what prec is returned for the !ok case)
-	var ok bool
-	switch s.prec, ok = s.Precision(); {
-	case !ok:
+	switch {
+	case !s.precOK:
 		s.prec = 0
-	case s.prec > 15:
-		// limit of 15 set by max power of 10 that is exactly representable
-		// as a float64.  later code depends on prec being in this range.
-		fmt.Fprintf(s, "%%!(BADPREC %d)", s.prec)
-		return nil // not a value error
+	case !s.sym.validPrec(s.prec):
+		b = append(b, "%!(BADPREC "...)
+		b = strconv.AppendInt(b, int64(s.prec), 10)
+		return append(b, ')'), nil // not a value error
+	}
+
+	if s.widthOK && s.sym.TotalWidth {
+		return s.appendTotalWidth(b, s.width)
 	}
 
-	// format validated, now preliminary checks on value:
+	if s.prec > 15 {
+		s.prec, s.bigPrec = 0, s.prec
+	}
+
+	// format validated, now preliminary checks on value.
+	// the result is appended to b.
 	var (
-		r   string
+		r   []byte
 		err error
 	)
 	switch {
 	case math.IsNaN(s.hrDeg):
 		err = ErrNaN
 		goto valErr
+	case s.limit > 0 && math.Abs(s.hrDeg) > s.limit:
+		if !math.IsInf(s.hrDeg, 0) {
+			err = ErrOutOfRange
+			goto valErr
+		}
+		if s.hrDeg > 0 {
+			err = ErrPosInf
+		} else {
+			err = ErrNegInf
+		}
+		goto valErr
 	case !math.IsInf(s.hrDeg, 0): // normal path
 	case math.IsInf(s.hrDeg, 1):
 		err = ErrPosInf
@@ -377,9 +1174,8 @@ func (s *state) writeFormatted() error {
 		goto valErr
 	}
 	// and then call the formatting method picked above
-	if r, err = f(); err == nil {
-		s.Write([]byte(r))
-		return nil // normal return
+	if r, err = s.formatValue(b); err == nil {
+		return s.appendPad(r, s.trail), nil // normal return
 	}
 
 	// If there was a value error, we output all '*'s
@@ -387,16 +1183,295 @@ func (s *state) writeFormatted() error {
 	// value with something valid and call format again to get a mock
 	// result, then use len(mock) for the number of '*'s to output.
 valErr:
+	err = s.formatError(err)
 	s.hrDeg = 0
-	width := 10 // default, defensive in case f somehow fails on 0.
-	if mock, err2 := f(); err2 == nil {
-		width = utf8.RuneCountInString(mock)
-		if strings.IndexRune(mock, s.sym.DecCombine) >= 0 {
-			width--
+	width := 10 // default, defensive in case formatting somehow fails on 0.
+	var mock [64]byte
+	if m, err2 := s.formatValue(mock[:0]); err2 == nil {
+		width = s.sym.displayWidth(m) + s.trail
+	}
+	fixed := s.widthOK
+	return s.appendOverflow(b, err, width, fixed), err
+}
+
+// displayWidth returns the width of formatted result r in runes, not
+// counting a combining DecCombine mark.
+func (sym *Symbols) displayWidth(r []byte) int {
+	n := utf8.RuneCount(r)
+	if bytes.ContainsRune(r, sym.DecCombine) {
+		n--
+	}
+	return n
+}
+
+// overflowText returns the text to output for a value that cannot be
+// formatted for reason err, or "" for a fill of the overflow rune.
+func (sym *Symbols) overflowText(err error) string {
+	switch {
+	case errors.Is(err, ErrNaN) && sym.NaNText > "":
+		return sym.NaNText
+	case errors.Is(err, ErrPosInf) && sym.PosInfText > "":
+		return sym.PosInfText
+	case errors.Is(err, ErrNegInf) && sym.NegInfText > "":
+		return sym.NegInfText
+	}
+	return sym.OverflowText
+}
+
+// formatError wraps err in a *FormatError describing the value and format.
+// checkFormat returns a *FormatError wrapping ErrBadVerb or ErrBadPrec
+// where writeFormatted would write "%!c(BADVERB)" or "%!(BADPREC)", for
+// callers that report these as errors.
+func (s *state) checkFormat() error {
+	prec := s.prec
+	if !s.precOK {
+		prec = 0
+	}
+	if err := s.sym.checkFormat(s.verb, prec); err != nil {
+		return s.formatError(err)
+	}
+	return nil
+}
+
+// checkFormat returns an error wrapping ErrBadVerb or ErrBadPrec if the verb
+// or precision prec is invalid with sym.  A nil sym means Default.
+func (sym *Symbols) checkFormat(verb rune, prec int) error {
+	if sym == nil {
+		sym = defaultSymbols()
+	}
+	switch {
+	case !validVerb(verb):
+		return fmt.Errorf("%w %%%c", ErrBadVerb, verb)
+	case verb != integerKey && !sym.validPrec(prec):
+		return fmt.Errorf("%w %d", ErrBadPrec, prec)
+	}
+	return nil
+}
+
+func (s *state) formatError(err error) error {
+	w := -1
+	if s.widthOK {
+		w = s.width
+	}
+	return &FormatError{Err: err, Value: s.hrDeg, Prec: s.prec + s.bigPrec,
+		Width: w}
+}
+
+// appendOverflow appends the output for a value that cannot be formatted
+// for reason err, for a result of width runes.  If fixed is false, a text
+// from the symbols is appended as is.  Otherwise it is fit to the width,
+// justified as for the '-' flag.
+func (s *state) appendOverflow(b []byte, err error, width int, fixed bool) []byte {
+	t := s.sym.overflowText(err)
+	if t == "" {
+		for o := s.sym.overflowRune(); width > 0; width-- {
+			b = utf8.AppendRune(b, o)
 		}
+		return b
 	}
-	s.Write(bytes.Repeat([]byte{'*'}, width))
-	return err
+	if !fixed {
+		return append(b, t...)
+	}
+	n := utf8.RuneCountInString(t)
+	switch {
+	case n <= width && s.flags&flagMinus != 0:
+		return appendSpaces(append(b, t...), width-n)
+	case n <= width:
+		return append(appendSpaces(b, width-n), t...)
+	}
+	for _, r := range t {
+		if width == 0 {
+			break
+		}
+		b = utf8.AppendRune(b, r)
+		width--
+	}
+	return b
+}
+
+// formatValue appends the value formatted by the method of s.form,
+// followed by a sign or hemisphere indicator if s has one, and with the
+// withDecimal verb, by the value as decimal hours or degrees in parentheses,
+// to five more decimal places.
+func (s *state) formatValue(b []byte) ([]byte, error) {
+	b, err := s.formatSigned(b)
+	if err != nil || s.verb != withDecimal {
+		return b, err
+	}
+	d := *s
+	d.form = formHrDeg
+	d.widthOK = false
+	d.flags &= flagPlus | flagSharp
+	d.prec, d.bigPrec = s.prec+5, 0
+	if d.prec > 15 || s.bigPrec > 0 {
+		d.prec = 15
+	}
+	b = append(b, " ("...)
+	if b, err = d.formatSigned(b); err != nil {
+		return nil, err
+	}
+	return append(b, ')'), nil
+}
+
+// formatSigned appends the value formatted by the method of s.form, followed
+// by a hemisphere indicator, the closing parenthesis of Symbols.NegParens,
+// or the sign of Symbols.TrailingSign, if s has one.
+func (s *state) formatSigned(b []byte) ([]byte, error) {
+	b, err := s.formatSegs(b)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case s.hemi[0] > "":
+		if s.neg {
+			return append(b, s.hemi[1]...), nil
+		}
+		return append(b, s.hemi[0]...), nil
+	case s.sym.NegParens:
+		return s.negParens(b), nil
+	case s.sym.TrailingSign:
+		return append(b, s.postSign...), nil
+	}
+	return b, nil
+}
+
+// negParens follows a negative result with the closing parenthesis of
+// Symbols.NegParens, or a non-negative one with SignPad if it has a sign
+// column.
+func (s *state) negParens(b []byte) []byte {
+	switch {
+	case s.neg && s.sym.NegParenClose > "":
+		return append(b, s.sym.NegParenClose...)
+	case s.neg:
+		return append(b, ')')
+	case s.flags&flagSpace == 0 && (!s.widthOK || s.noSignPad):
+		return b
+	case s.sym.SignPad > "":
+		return append(b, s.sym.SignPad...)
+	}
+	return append(b, ' ')
+}
+
+// formatSegs appends the value formatted by the method of s.form.
+func (s *state) formatSegs(b []byte) ([]byte, error) {
+	switch s.form {
+	case formMin:
+		return s.decimalMin(b)
+	case formHrDeg:
+		return s.decimalHrDeg(b)
+	case formCompact:
+		return s.compact(b)
+	case formTotalSec:
+		return s.totalSec(b)
+	case formTotalMin:
+		return s.totalMin(b)
+	case formTurns:
+		return s.turns(b)
+	}
+	return s.decimalSec(b)
+}
+
+// sigFigsPrec returns the precision showing n significant digits of the
+// value in the segments of s.verb.
+func (s *state) sigFigsPrec(n int) int {
+	x := math.Abs(s.hrDeg)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0
+	}
+	scale, seg := 3600., 2 // scale of the decimal segment, segments before it
+	switch s.verb {
+	case hrDegAppend, hrDegCombine, hrDegInsert:
+		scale, seg = 1, 0
+	case minAppend, minCombine, minInsert:
+		scale, seg = 60, 1
+	case totalSec:
+		seg = 0
+	case totalMin:
+		scale, seg = 60, 0
+	case turns:
+		scale, seg = s.turnScale(), 0
+	}
+	// integer digits from the first non-zero segment, or if there is none,
+	// less the zeros following the decimal point.
+	digits := 1
+	if v := x * scale; v < 1 {
+		if v > 0 {
+			digits = int(math.Floor(math.Log10(v))) + 1
+		}
+	} else {
+		for j, m := 0, scale/math.Pow(60, float64(seg)); ; j, m = j+1, m*60 {
+			if sv := math.Floor(x * m); sv >= 1 {
+				digits = len(strconv.FormatFloat(sv, 'f', 0, 64)) + 2*(seg-j)
+				break
+			}
+		}
+	}
+	switch p := n - digits; {
+	case p < 0:
+		return 0
+	case p > 15:
+		return 15
+	default:
+		return p
+	}
+}
+
+// padRune returns Symbols.PadRune, or ' ' if it is not set.
+func (s *state) padRune() rune {
+	if s.sym.PadRune != 0 {
+		return s.sym.PadRune
+	}
+	return ' '
+}
+
+// appendPad appends n of the pad rune to b.
+func (s *state) appendPad(b []byte, n int) []byte {
+	for r := s.padRune(); n > 0; n-- {
+		b = utf8.AppendRune(b, r)
+	}
+	return b
+}
+
+// appendSpaces appends n spaces to b.
+func appendSpaces(b []byte, n int) []byte {
+	for ; n > 0; n-- {
+		b = append(b, ' ')
+	}
+	return b
+}
+
+// appendTotalWidth formats to the total width w, appending the result to b.
+// The value is formatted as if no width were given, then padded to w.
+func (s *state) appendTotalWidth(b []byte, w int) ([]byte, error) {
+	inner := *s
+	inner.width, inner.widthOK = 0, false
+	start := len(b)
+	b, err := inner.appendFormat(b)
+	if fe, ok := err.(*FormatError); ok {
+		fe.Width = w
+	}
+	n := runeWidth(string(b[start:]))
+	switch {
+	case err == nil && n > w:
+		err = ErrHourOverflow
+		if s.caller == fsAngle {
+			err = ErrDegreeOverflow
+		}
+		err = &FormatError{Err: err, Value: s.hrDeg, Prec: s.prec, Width: w}
+		fallthrough
+	case err != nil:
+		return s.appendOverflow(b[:start], err, w, true), err
+	case s.flags&flagMinus != 0:
+		return appendSpaces(b, w-n), nil
+	}
+	// shift the result right to make room for the padding
+	pad := w - n
+	b = appendSpaces(b, pad)
+	copy(b[start+pad:], b[start:len(b)-pad])
+	for i := start; i < start+pad; i++ {
+		b[i] = ' '
+	}
+	return b, nil
 }
 
 var (
@@ -410,172 +1485,517 @@ var (
 //
 // x must be >= 0.  prec must be 0..15.
 //
-// the digits are returned as xs = int64(x * 10**prec + .5), as long as
-// the result xs is small enough that all digits are significant given
-// float64 representation.
+// the digits are returned as xs = x * 10**prec rounded to an integer
+// according to mode, as long as the result xs is small enough that all
+// digits are significant given float64 representation.
 // if xs does not represent a fully significant result -1 is returned.
-func sig(x float64, prec int) int64 {
-	xs := x*tenf[prec] + .5
-	if !(xs <= 1<<52) { // 52 mantissa bits in float64
+func sig(x float64, prec int, mode RoundingMode) int64 {
+	xs := x * tenf[prec]
+	if !(xs+.5 <= 1<<52) { // 52 mantissa bits in float64
+		return sigExact(x, prec)
+	}
+	i := int64(xs)
+	switch d := xs - float64(i); mode {
+	case RoundHalfEven:
+		if d > .5 || d == .5 && i&1 == 1 {
+			i++
+		}
+	case RoundTowardZero:
+		// a value within a few ulps below a digit, as from conversion of
+		// units, is taken as the digit, not truncated to the one before.
+		if 1-d <= xs*0x1p-50 {
+			i++
+		}
+	default:
+		if d >= .5 {
+			i++
+		}
+	}
+	return i
+}
+
+// roundScaled rounds x to a multiple of 1/scale by the rounding mode, as sig
+// does at precision 0.  A value beyond the resolution of sig, including
+// +Inf, -Inf, and NaN, is returned unchanged.
+func roundScaled(x, scale float64, mode RoundingMode) float64 {
+	i := sig(math.Abs(x)*scale, 0, mode)
+	if i < 0 {
+		return x
+	}
+	return math.Copysign(float64(i)/scale, x)
+}
+
+// sigExact returns x*10^prec as an integer when that is exact, as it is for
+// a whole number x, so that the requested digits are all significant even
+// beyond the 52 bit limit of sig.  Otherwise it returns -1.
+func sigExact(x float64, prec int) int64 {
+	w, f := math.Modf(x)
+	fs := f * tenf[prec]
+	if !(w < 1<<62) || fs != math.Trunc(fs) || math.FMA(f, tenf[prec], -fs) != 0 {
 		return -1
 	}
-	return int64(xs)
+	i := int64(w)
+	if i > (math.MaxInt64-int64(fs))/teni[prec] {
+		return -1
+	}
+	return i*teni[prec] + int64(fs)
+}
+
+// maxBigPrec is the maximum precision with Symbols.HighPrecision.
+const maxBigPrec = 40
+
+// validVerb reports whether verb is a verb of the custom formatters.
+func validVerb(verb rune) bool {
+	switch verb {
+	case 'v', secAppend, secCombine, secInsert, minAppend, minCombine,
+		minInsert, hrDegAppend, hrDegCombine, hrDegInsert, compact, totalSec,
+		totalMin, unitless, withDecimal, turns, integerKey:
+		return true
+	}
+	return false
 }
 
-func (s *state) decimalHrDeg() (string, error) {
-	i := sig(math.Abs(s.hrDeg), s.prec)
+// validPrec reports whether prec is a valid precision with sym, 0 to 15, or
+// to maxBigPrec with HighPrecision.  the limit of 15 is set by the max power
+// of 10 that is exactly representable as a float64.  later code depends on
+// prec being in this range.
+func (sym *Symbols) validPrec(prec int) bool {
+	return prec >= 0 && (prec <= 15 || prec <= maxBigPrec && sym.HighPrecision)
+}
+
+// round returns |hrDeg| scaled by scale, rounded to the precision, as sig
+// does.  For a precision above 15 it returns the whole part, which is 0 for
+// any value with the significance, and leaves the decimal places in s.frac.
+func (s *state) round(scale float64) int64 {
+	if s.bigPrec == 0 {
+		return sig(math.Abs(s.hrDeg)*scale, s.prec, s.sym.Rounding)
+	}
+	i := bigSig(math.Abs(s.hrDeg), scale, s.bigPrec, s.sym.Rounding)
 	if i < 0 {
-		return "", ErrLossOfPrecision
+		return -1
 	}
-	if s.hrDeg < 0 {
-		i = -i
+	s.frac = appendPadInt(s.frac[:0], i, s.bigPrec, '0')
+	return 0
+}
+
+// bigSig is sig for precisions above 15.  x*scale is computed exactly, and
+// the result has the same limit of 52 bits.
+func bigSig(x, scale float64, prec int, mode RoundingMode) int64 {
+	xs := new(big.Float).SetPrec(256).SetFloat64(x)
+	xs.Mul(xs, big.NewFloat(scale))
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil)
+	xs.Mul(xs, new(big.Float).SetInt(p))
+	if xs.Cmp(big.NewFloat(1<<52-.5)) > 0 {
+		return -1
 	}
-	var r, f string
-	if wid, widSpec := s.Width(); !widSpec {
-		if s.Flag('+') {
-			f = "%+0*d"
-		} else if s.Flag(' ') { // sign space if requested
-			f = "% 0*d"
-		} else {
-			f = "%0*d"
+	i, _ := xs.Int64() // truncated
+	d := xs.Sub(xs, new(big.Float).SetInt64(i)).Cmp(big.NewFloat(.5))
+	switch mode {
+	case RoundHalfEven:
+		if d > 0 || d == 0 && i&1 == 1 {
+			i++
 		}
-		// +1 forces at least one place left of decimal point
-		r = fmt.Sprintf(f, s.prec+1, i)
-	} else {
-		// fixed width a little more involved
-		if s.Flag('+') {
-			f = "%+"
-		} else {
-			f = "% " // sign space forced with fixed width
+	case RoundTowardZero:
+	default:
+		if d >= 0 {
+			i++
 		}
-		if s.Flag('0') {
-			f += "0*d"
-		} else {
-			f += "*d"
+	}
+	return i
+}
+
+// negative returns whether the value is negative once rounded, with i the
+// result of round.
+func (s *state) negative(i int64) bool {
+	return s.hrDeg < 0 && (i > 0 || len(bytes.Trim(s.frac, "0")) > 0)
+}
+
+func (s *state) decimalHrDeg(b []byte) ([]byte, error) {
+	return s.decimalSeg(b, 1, s.units.HrDeg)
+}
+
+// totalSec formats the value as a single segment of seconds.
+func (s *state) totalSec(b []byte) ([]byte, error) {
+	return s.decimalSeg(b, 3600, s.units.Sec)
+}
+
+// totalMin formats the value as a single segment of minutes.
+func (s *state) totalMin(b []byte) ([]byte, error) {
+	return s.decimalSeg(b, 60, s.units.Min)
+}
+
+// turns formats the value as a single segment of a fraction of a turn, with
+// the unit Symbols.RevUnit.
+func (s *state) turns(b []byte) ([]byte, error) {
+	return s.decimalSeg(b, s.turnScale(), s.sym.RevUnit)
+}
+
+// turnScale returns the scale of hrDeg to turns, 360° or 24 hours.
+func (s *state) turnScale() float64 {
+	if s.caller == fsAngle {
+		return 1. / 360
+	}
+	return 1. / 24
+}
+
+// decimalSeg formats the value as a single decimal segment, hrDeg scaled by
+// scale, with the given unit.
+func (s *state) decimalSeg(b []byte, scale float64, unit string) ([]byte, error) {
+	i := s.round(scale)
+	if i < 0 {
+		return nil, ErrLossOfPrecision
+	}
+	wid, widSpec := s.width, s.widthOK
+	if m := s.sym.MaxIntDigits; m > 0 && m < len(teni) && !widSpec &&
+		scale == 1 && i/teni[s.prec] >= teni[m] {
+		if s.caller == fsAngle {
+			return nil, ErrDegreeOverflow
 		}
-		wf := s.prec + wid + 1 // +1 here is required space for sign
-		r = fmt.Sprintf(f, wf, i)
+		return nil, ErrHourOverflow
+	}
+	// +1 forces at least one place left of decimal point
+	var d [24]byte
+	r := appendPadInt(d[:0], i, s.prec+1, '0')
+	sign := s.sign(s.negative(i))
+	if !widSpec {
+		b = append(b, sign...)
+	} else {
+		// fixed width a little more involved.  r holds only ASCII digits,
+		// so its length is its width whatever the sign and unit symbols.
+		wf := s.prec + wid
 		if len(r) > wf {
 			if s.caller == fsAngle {
-				return "", ErrDegreeOverflow
+				return nil, ErrDegreeOverflow
 			}
-			return "", ErrHourOverflow
+			return nil, ErrHourOverflow
+		}
+		pad := s.groupWidth(wid) - s.groupWidth(len(r)-s.prec)
+		switch {
+		case s.flags&flagMinus != 0:
+			// padding moves to the end of the result.  as with package
+			// fmt, this takes precedence over the '0' flag.
+			s.trail = pad
+			b = append(b, sign...)
+		case s.flags&flagZero != 0:
+			b = append(b, sign...)
+			r = appendPadInt(d[:0], i, wf, '0')
+		case s.padRune() != ' ':
+			// sign column first, as in firstSeg, so that leaders run up
+			// to the digits
+			b = s.appendPad(append(b, sign...), pad)
+		default:
+			// sign immediately in front of the number
+			b = append(s.appendPad(b, pad), sign...)
+		}
+	}
+	// the '#' flag shows the decimal separator even at precision 0
+	s.sharpSep = s.flags&flagSharp != 0 && s.verb != integerKey
+	return s.appendDecimal(b, r, unit), nil
+}
+
+// appendDecimal appends the decimal segment with digits r, which end with
+// s.prec decimal places, followed by s.frac for a precision above 15, and
+// the unit.  The decimal separator and unit are
+// placed according to the decimal unit convention of the verb.  Trailing
+// zeros are first removed if the symbols call for it.
+func (s *state) appendDecimal(b, r []byte, unit string) []byte {
+	p := s.prec
+	if s.bigPrec > 0 {
+		r = append(r, s.frac...)
+		p += len(s.frac)
+	}
+	if s.sym.TrimTrailingZeros && s.flags&flagSharp == 0 {
+		for p > 0 && r[len(r)-1] == '0' {
+			r = r[:len(r)-1]
+			p--
 		}
 	}
-	if s.prec > 0 {
-		split := len(r) - s.prec
-		r = r[:split] + s.sym.DecSep + r[split:]
+	split := len(r) - p
+	b = s.appendGrouped(b, r[:split])
+	if p == 0 && (!s.sharpSep || s.sym.DecSep == "") {
+		// no separator, so the combined and inserted conventions are the
+		// same as the following convention.  no DoubleUnit either.
+		return append(b, unit...)
 	}
+	var combine, insert bool
 	switch s.verb {
-	case hrDegAppend:
-		r += string(s.units.HrDeg)
-	case hrDegCombine:
-		r = s.sym.CombineUnit(r, s.units.HrDeg)
-	case hrDegInsert:
-		r = s.sym.InsertUnit(r, s.units.HrDeg)
+	case secCombine, minCombine, hrDegCombine:
+		// without a DecCombine rune, or without digits to combine with as
+		// with the '#' flag at precision 0, combining falls back to
+		// inserting
+		combine = s.sym.DecCombine != 0 && p > 0
+		insert = !combine
+	case secInsert, minInsert, hrDegInsert:
+		insert = true
+	}
+	switch {
+	case combine && s.sym.DecSep > "" && s.sym.DecCombine != 0:
+		// unit, then DecCombine replacing DecSep
+		b = append(b, unit...)
+		b = utf8.AppendRune(b, s.sym.DecCombine)
+	case insert && s.sym.DecSep > "":
+		// unit before DecSep
+		b = append(b, unit...)
+		b = append(b, s.sym.DecSep...)
+	default:
+		b = append(b, s.sym.DecSep...)
+		b = append(b, r[split:]...)
+		b = append(b, unit...)
+		if combine || insert {
+			b = append(b, s.doubleUnit(unit)...)
+		}
+		return b
 	}
-	return r, nil
+	b = append(b, r[split:]...)
+	return append(b, s.doubleUnit(unit)...)
 }
 
-func (s *state) decimalMin() (string, error) {
-	i := sig(math.Abs(s.hrDeg)*60, s.prec) // hrDeg*60 gets minutes
+// compact formats with the fewest segments that show the value exactly at
+// the precision.  It selects the verb each time it is called.
+func (s *state) compact(b []byte) ([]byte, error) {
+	if s.flags&flagSharp == 0 {
+		// a loss of precision here is reported by decimalSec
+		if i := s.round(3600); i >= 0 && len(bytes.Trim(s.frac, "0")) == 0 {
+			switch p60 := 60 * teni[s.prec]; {
+			case i%(60*p60) == 0:
+				s.verb = hrDegAppend
+				return s.decimalHrDeg(b)
+			case i%p60 == 0:
+				s.verb = minAppend
+				return s.decimalMin(b)
+			}
+		}
+	}
+	s.verb = secAppend
+	return s.decimalSec(b)
+}
+
+func (s *state) decimalMin(b []byte) ([]byte, error) {
+	i := s.round(60) // hrDeg*60 gets minutes
 	if i < 0 {
-		return "", ErrLossOfPrecision
+		return nil, ErrLossOfPrecision
 	}
 	p60 := 60 * teni[s.prec]
 	min := i / p60
 	sec := i % p60
 
-	r, minEl, err := s.firstSeg(min)
+	b, minEl, err := s.firstSeg(b, min, s.negative(i))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return r + s.lastSeg(sec, s.units.Min, minEl), nil
+	return s.lastSeg(b, sec, s.units.Min, minEl), nil
 }
 
-func (s *state) firstSeg(x int64) (r string, elided bool, err error) {
-	wid, widSpec := s.Width()
+// firstSeg appends the sign and the first segment, x, of a sexagesimal
+// format.  Neg is whether the value is negative after rounding.
+func (s *state) firstSeg(b []byte, x int64, neg bool) (r []byte, elided bool, err error) {
+	wid, widSpec := s.width, s.widthOK
+	b = append(b, s.sign(neg)...)
+	// with a day unit, a Time can have a days segment ahead of hours
+	unit, hr := s.units.HrDeg, int64(-1)
+	if s.caller == fsTime && s.sym.DayUnit > "" && s.verb != unitless &&
+		(x >= 24 || widSpec || s.flags&flagSharp != 0) {
+		x, hr = x/24, x%24
+		unit = s.sym.DayUnit
+	}
 	switch {
 	case widSpec:
-		f := "%*d"
-		if s.Flag('0') {
-			f = "%0*d"
-		}
-		r = fmt.Sprintf(f, wid, x)
+		var d [20]byte
+		r := strconv.AppendInt(d[:0], x, 10) // digits only, as in decimalSeg
 		if len(r) > wid {
 			if s.caller == fsAngle {
-				return "", false, ErrDegreeOverflow
+				return nil, false, ErrDegreeOverflow
 			}
-			return "", false, ErrHourOverflow
+			return nil, false, ErrHourOverflow
+		}
+		pad := s.groupWidth(wid) - s.groupWidth(len(r))
+		switch {
+		case s.flags&flagMinus != 0:
+			// padding moves to the end of the result.  as with package
+			// fmt, this takes precedence over the '0' flag.
+			b = s.appendGrouped(b, r)
+			s.trail = pad
+		case s.flags&flagZero != 0:
+			b = s.appendGrouped(b, appendPadInt(d[:0], x, wid, '0'))
+		default:
+			b = s.appendGrouped(s.appendPad(b, pad), r)
 		}
-		r += s.units.HrDeg
-	case x > 0 || s.Flag('#'):
-		r = fmt.Sprintf("%d%s", x, s.units.HrDeg)
+		b = append(b, unit...)
+	case x > 0 || s.flags&flagSharp != 0:
+		var d [20]byte
+		b = s.appendGrouped(b, strconv.AppendInt(d[:0], x, 10))
+		b = append(b, unit...)
 	default:
 		elided = true
 	}
+	if hr >= 0 {
+		switch {
+		case s.padSegs():
+			b = appendPadInt(b, hr, 2, '0')
+		case widSpec:
+			b = appendPadInt(b, hr, 2, ' ')
+		default:
+			b = strconv.AppendInt(b, hr, 10)
+		}
+		b = append(b, s.units.HrDeg...)
+	}
+	return b, elided, nil
+}
+
+// padSegs reports whether segments following the first are zero padded to
+// two digits, with the '0' flag or Symbols.PadSexagesimal.
+func (s *state) padSegs() bool {
+	return s.flags&flagZero != 0 || s.sym.PadSexagesimal
+}
+
+// groupWidth returns the width in runes of n integer digits with
+// Symbols.GroupSep.
+func (s *state) groupWidth(n int) int {
+	if n > 3 {
+		n += (n - 1) / 3 * utf8.RuneCountInString(s.sym.GroupSep)
+	}
+	return n
+}
+
+// appendGrouped appends integer digits r, with Symbols.GroupSep separating
+// groups of three.
+func (s *state) appendGrouped(b, r []byte) []byte {
+	if s.sym.GroupSep == "" {
+		return append(b, r...)
+	}
+	for i, c := range r {
+		if i > 0 && (len(r)-i)%3 == 0 {
+			b = append(b, s.sym.GroupSep...)
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+// appendPadInt appends non-negative x in decimal, padded on the left with
+// pad to at least wid digits.
+func appendPadInt(b []byte, x int64, wid int, pad rune) []byte {
+	var d [20]byte
+	r := strconv.AppendInt(d[:0], x, 10)
+	for n := len(r); n < wid; n++ {
+		b = utf8.AppendRune(b, pad)
+	}
+	return append(b, r...)
+}
+
+// sign returns the sign indicator for a value, negative or not.
+//
+// Non-negative values get Symbols.PosSign with the '+' flag or SignPad with
+// the ' ' flag or a fixed width.  With Symbols.TrailingSign the indicator is
+// left in s.postSign and sign returns "".
+func (s *state) sign(neg bool) string {
+	s.neg = neg
+	if s.hemi[0] > "" {
+		return "" // indicated by hemisphere instead
+	}
+	if s.sym.TrailingSign && !s.sym.NegParens {
+		s.postSign = s.leadingSign(neg)
+		return ""
+	}
+	return s.leadingSign(neg)
+}
+
+// leadingSign returns the sign indicator as it would lead the value.
+func (s *state) leadingSign(neg bool) string {
+	widSpec := s.widthOK
 	switch {
-	case s.hrDeg < 0:
-		r = "-" + r
-	case s.Flag('+'):
-		r = "+" + r
-	case s.Flag(' ') || widSpec:
-		r = " " + r
+	case neg && s.sym.NegParens:
+		if s.sym.NegParenOpen > "" {
+			return s.sym.NegParenOpen
+		}
+		return "("
+	case neg:
+		if s.sym.NegSign > "" {
+			return s.sym.NegSign
+		}
+		return "-"
+	case s.flags&flagPlus != 0:
+		if s.sym.PosSign > "" {
+			return s.sym.PosSign
+		}
+		return "+"
+	case s.flags&flagSpace != 0 || widSpec && !s.noSignPad:
+		return s.sym.signPad()
+	}
+	return ""
+}
+
+// signPad returns sym.SignPad, or by default spaces as wide as the wider of
+// PosSign and NegSign.
+func (sym *Symbols) signPad() string {
+	if sym.SignPad > "" {
+		return sym.SignPad
 	}
-	return r, elided, nil
+	n := max(runeWidth(sym.PosSign), runeWidth(sym.NegSign), 1)
+	return strings.Repeat(" ", n)
 }
 
-func (s *state) lastSeg(sec int64, unit string, first bool) string {
+func (s *state) lastSeg(b []byte, sec int64, unit string, first bool) []byte {
 	wid := s.prec + 1
-	_, widSpec := s.Width()
-	if s.Flag('0') && (widSpec || !first) {
+	widSpec := s.widthOK
+	if s.padSegs() && !first || s.flags&flagZero != 0 && widSpec {
 		wid++
 	}
-	r := fmt.Sprintf("%0*d", wid, sec)
+	var d [24]byte
+	r := appendPadInt(d[:0], sec, wid, '0')
 	if widSpec && len(r) < s.prec+2 {
-		r = " " + r
-	}
-	if s.prec > 0 {
-		split := len(r) - s.prec
-		r = r[:split] + s.sym.DecSep + r[split:]
+		b = append(b, ' ')
 	}
-	switch s.verb {
-	case secCombine, minCombine:
-		return s.sym.CombineUnit(r, unit)
-	case secInsert, minInsert:
-		return s.sym.InsertUnit(r, unit)
+	s.sharpSep = false
+	return s.appendDecimal(b, r, unit)
+}
+
+// doubleUnit returns the unit to repeat at the end of a combined or inserted
+// decimal segment, or "" if Symbols.DoubleUnit is not set or there is no
+// decimal separator.
+func (s *state) doubleUnit(unit string) string {
+	if s.sym.DoubleUnit && s.sym.DecSep > "" {
+		return unit
 	}
-	return r + unit
+	return ""
 }
 
-func (s *state) decimalSec() (string, error) {
-	i := sig(math.Abs(s.hrDeg)*3600, s.prec) // hrDeg*3600 gets seconds
+func (s *state) decimalSec(b []byte) ([]byte, error) {
+	i := s.round(3600) // hrDeg*3600 gets seconds
 	if i < 0 {
-		return "", ErrLossOfPrecision
+		return nil, ErrLossOfPrecision
 	}
+	neg := s.negative(i)
 	p60 := 60 * teni[s.prec]
 	sec := i % p60
 	i /= p60
 	min := i % 60
 	hrDeg := i / 60
-	r, firstEl, err := s.firstSeg(hrDeg)
+	b, firstEl, err := s.firstSeg(b, hrDeg, neg)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	f := "%s%d%s"
 	minEl := false
-	if s.Flag('0') && !firstEl {
-		f = "%s%02d%s"
+	if s.padSegs() && !firstEl {
+		b = appendPadInt(b, min, 2, '0')
 	} else {
-		switch _, widSpec := s.Width(); {
+		// with a width, firstSeg never elides, '#' being implied, and
+		// minutes must not be elided either.  the width case comes first.
+		switch widSpec := s.widthOK; {
 		case widSpec:
-			f = "%s%2d%s"
+			b = appendPadInt(b, min, 2, ' ')
 		case firstEl && min == 0:
 			minEl = true
 			goto last
+		default:
+			b = strconv.AppendInt(b, min, 10)
 		}
 	}
-	r = fmt.Sprintf(f, r, min, s.units.Min)
+	b = append(b, s.units.Min...)
+	if s.sym.ElideZeroSeconds && sec == 0 && s.prec+s.bigPrec == 0 &&
+		!s.widthOK && s.verb != unitless {
+		return b, nil
+	}
 last:
-	return r + s.lastSeg(sec, s.units.Sec, minEl), nil
+	return s.lastSeg(b, sec, s.units.Sec, minEl), nil
 }
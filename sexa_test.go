@@ -3,9 +3,15 @@
 package sexa_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/soniakeys/sexagesimal"
 	"github.com/soniakeys/unit"
@@ -39,6 +45,18 @@ func TestCombineUnit_No_DecSep(t *testing.T) {
 	}
 }
 
+// Without a DecCombine rune, combining falls back to inserting.
+func TestCombineUnit_No_DecCombine(t *testing.T) {
+	sym := &sexa.Symbols{DecSep: "."}
+	if got, want := sym.CombineUnit("1.25", "d"), "1d.25"; got != want {
+		t.Error("got", got, "want", want)
+	}
+	a := sexa.FmtAngleASCII(unit.NewAngle(' ', 1, 2, 3.4))
+	if got, want := fmt.Sprintf("%.1c", a), fmt.Sprintf("%.1d", a); got != want {
+		t.Error("got", got, "want", want)
+	}
+}
+
 func ExampleInsertUnit() {
 	formatted := "1.25"
 	fmt.Println("Decimal point:", formatted)
@@ -177,6 +195,38 @@ func TestStrip(t *testing.T) {
 	}
 }
 
+// With DecSep repeated, as when it also separates digit groups, the unit
+// goes with the last occurrence.
+func TestRepeatedDecSep(t *testing.T) {
+	d := "1.234.56"
+	if got := sexa.InsertUnit(d, "°"); got != "1.234°.56" {
+		t.Errorf("InsertUnit: got %q", got)
+	} else if s, ok := sexa.StripUnit(got, "°"); s != d || !ok {
+		t.Errorf("StripUnit(%q) = %q, %t", got, s, ok)
+	}
+	if got := sexa.CombineUnit(d, "°"); got != "1.234°\u032356" {
+		t.Errorf("CombineUnit: got %q", got)
+	} else if s, ok := sexa.StripUnit(got, "°"); s != d || !ok {
+		t.Errorf("StripUnit(%q) = %q, %t", got, s, ok)
+	}
+}
+
+// A unit that also appears in the number must be matched at the end or
+// before the decimal separator, not at its first occurrence.
+func TestStripDigitUnit(t *testing.T) {
+	for _, tc := range []struct{ d, unit, want string }{
+		{"5.25", "5", "5.2"},   // unit at end, not before DecSep
+		{"515.3", "5", "51.3"}, // unit before DecSep, not at start
+		{"12.3", "1", "12.3"},  // no match adjacent to DecSep or end
+	} {
+		got, ok := sexa.StripUnit(tc.d, tc.unit)
+		if got != tc.want || ok != (got != tc.d) {
+			t.Errorf("StripUnit(%q, %q) = %q, %t, want %q",
+				tc.d, tc.unit, got, ok, tc.want)
+		}
+	}
+}
+
 func ExampleAngle() {
 	f := sexa.FmtAngle(unit.NewAngle(' ', 180, 0, 0))
 	fmt.Println(f)
@@ -186,6 +236,29 @@ func ExampleAngle() {
 	// sexa.Angle{Angle:3.141592653589793, Sym:(*sexa.Symbols)(nil), Err:error(nil)}
 }
 
+func ExampleFmt() {
+	for _, v := range []interface{}{
+		unit.NewAngle('-', 13, 47, 22),
+		unit.NewHourAngle('-', 1, 47, 22),
+		unit.NewRA(1, 47, 22),
+		unit.NewTime(' ', 0, 22, 7),
+		13.5,
+	} {
+		f, err := sexa.Fmt(v)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("%T %s\n", f, f)
+	}
+	// Output:
+	// *sexa.Angle -13°47′22″
+	// *sexa.HourAngle -1ʰ47ᵐ22ˢ
+	// *sexa.RA 1ʰ47ᵐ22ˢ
+	// *sexa.Time 22ᵐ7ˢ
+	// Unsupported type float64
+}
+
 func ExampleFmtAngle() {
 	a := unit.NewAngle('-', 13, 47, 22)
 	f := sexa.FmtAngle(a)
@@ -194,6 +267,13 @@ func ExampleFmtAngle() {
 	// *sexa.Angle -13°47′22″
 }
 
+func ExampleFmtAngleASCII() {
+	f := sexa.FmtAngleASCII(unit.NewAngle('-', 13, 47, 22.5))
+	fmt.Printf("%.1s\n", f)
+	// Output:
+	// -13d47m22.5s
+}
+
 func ExampleAngle_String() {
 	a := sexa.FmtAngle(unit.NewAngle(' ', 23, 26, 44))
 	s := a.String()
@@ -219,6 +299,13 @@ func ExampleFmtHourAngle() {
 	// *sexa.HourAngle -1ʰ47ᵐ22ˢ
 }
 
+func ExampleFmtHourAngleASCII() {
+	f := sexa.FmtHourAngleASCII(unit.NewHourAngle('-', 1, 47, 22))
+	fmt.Println(f)
+	// Output:
+	// -1h47m22s
+}
+
 func ExampleHourAngle_String() {
 	h := sexa.FmtHourAngle(unit.NewHourAngle('-', 2, 34, 45.6))
 	s := h.String()
@@ -244,6 +331,13 @@ func ExampleFmtRA() {
 	// *sexa.RA 1ʰ47ᵐ22ˢ
 }
 
+func ExampleFmtRAASCII() {
+	f := sexa.FmtRAASCII(unit.NewRA(1, 47, 22))
+	fmt.Printf("%.2m\n", f)
+	// Output:
+	// 1h47.37m
+}
+
 func ExampleRA_String() {
 	ra := sexa.FmtRA(unit.NewRA(12, 34, 45.6))
 	s := ra.String()
@@ -269,6 +363,13 @@ func ExampleFmtTime() {
 	// *sexa.Time -15ʰ22ᵐ7ˢ
 }
 
+func ExampleFmtTimeASCII() {
+	f := sexa.FmtTimeASCII(unit.NewTime('-', 15, 22, 7))
+	fmt.Printf("%#0s\n", f)
+	// Output:
+	// -15h22m07s
+}
+
 func ExampleTime_String() {
 	t := sexa.FmtTime(unit.NewTime(0, 12, 34, 45.6))
 	s := t.String()
@@ -301,7 +402,7 @@ func TestLeadingZero(t *testing.T) {
 func ExampleSymbols_CombineUnit() {
 	formatted := "1,25"
 	fmt.Println("Decimal comma:", formatted)
-	c := sexa.Default
+	c := sexa.DefaultSymbols()
 	c.DecSep = ","
 	c.DecCombine = '\u0326' // combining comma below
 	// Note that some software may not render the combining comma well.
@@ -373,13 +474,13 @@ func ExampleSymbols_FmtTime() {
 }
 
 func TestCoverage(t *testing.T) {
-	f := sexa.FmtAngle(unit.AngleFromDeg(9))
+	f := sexa.FmtAngle(unit.AngleFromDeg(9.1))
 	want := "******************"
 	got := fmt.Sprintf("%.15h", f)
 	if got != want {
 		t.Error(got, want)
 	}
-	if f.Err != sexa.ErrLossOfPrecision {
+	if !errors.Is(f.Err, sexa.ErrLossOfPrecision) {
 		t.Error(f.Err, sexa.ErrLossOfPrecision)
 	}
 	want = "*****************"
@@ -387,7 +488,7 @@ func TestCoverage(t *testing.T) {
 	if got != want {
 		t.Error(got, want)
 	}
-	if f.Err != sexa.ErrLossOfPrecision {
+	if !errors.Is(f.Err, sexa.ErrLossOfPrecision) {
 		t.Error(f.Err, sexa.ErrLossOfPrecision)
 	}
 
@@ -422,7 +523,7 @@ func TestCoverage(t *testing.T) {
 	if got != want {
 		t.Error(got, want)
 	}
-	if f.Err != sexa.ErrDegreeOverflow {
+	if !errors.Is(f.Err, sexa.ErrDegreeOverflow) {
 		t.Error(f.Err, sexa.ErrDegreeOverflow)
 	}
 	tf := sexa.FmtTime(unit.TimeFromHour(102))
@@ -431,7 +532,7 @@ func TestCoverage(t *testing.T) {
 	if got != want {
 		t.Error(got, want)
 	}
-	if tf.Err != sexa.ErrHourOverflow {
+	if !errors.Is(tf.Err, sexa.ErrHourOverflow) {
 		t.Error(tf.Err, sexa.ErrHourOverflow)
 	}
 
@@ -450,3 +551,1996 @@ func TestCoverage(t *testing.T) {
 		t.Error(got, wantOverf)
 	}
 }
+
+func TestMaxIntDigits(t *testing.T) {
+	s := sexa.Symbols{DMSUnits: sexa.UnitSymbols{"°", "′", "″"},
+		DecSep: ".", MaxIntDigits: 3}
+	f := s.FmtAngle(unit.AngleFromDeg(359.5))
+	if got := fmt.Sprintf("%.1h", f); got != "359.5°" {
+		t.Fatal(got)
+	}
+	f.Angle = unit.AngleFromDeg(1359.5)
+	if got := fmt.Sprintf("%.1h", f); got != "****" {
+		t.Fatal(got)
+	}
+	if !errors.Is(f.Err, sexa.ErrDegreeOverflow) {
+		t.Fatal(f.Err)
+	}
+	// width takes precedence
+	if got := fmt.Sprintf("%4.1h", f); got != " 1359.5°" {
+		t.Fatal(got)
+	}
+	// other formats are not limited
+	if got := fmt.Sprintf("%s", f); got != "1359°30′0″" {
+		t.Fatal(got)
+	}
+}
+
+func ExampleIsOverflowOutput() {
+	s := fmt.Sprintf("%2s", sexa.FmtAngle(unit.NewAngle(' ', 135, 0, 0)))
+	fmt.Println(s, sexa.IsOverflowOutput(s, nil))
+	sym := &sexa.Symbols{OverflowRune: '#'}
+	s = fmt.Sprintf("%2s", sym.FmtAngle(unit.NewAngle(' ', 135, 0, 0)))
+	fmt.Println(s, sexa.IsOverflowOutput(s, sym))
+	fmt.Println(sexa.IsOverflowOutput("", nil))
+	// Output:
+	// ********** true
+	// ####### true
+	// false
+}
+
+// visible width, not counting combining marks
+func visWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		if !unicode.Is(unicode.Mn, r) {
+			n++
+		}
+	}
+	return n
+}
+
+// Fixed width overflow of decimal minute and decimal hour/degree formats
+// gives the error for the type and asterisks filling the width the value
+// would have had.
+func TestFixedWidthOverflow(t *testing.T) {
+	for _, f := range []string{
+		"%2m", "%2.1m", "%2.1n", "%2.1o", "%+2.2m", "%02.2m",
+		"%2h", "%2.2h", "%2.2i", "%2.2j", "%+2.2h", "%02.2h",
+		"%2.1s", "%2.1c", "%2.1d",
+	} {
+		a := sexa.FmtAngle(unit.AngleFromDeg(12.456))
+		tm := sexa.FmtTime(unit.TimeFromHour(12.456))
+		va := fmt.Sprintf(f, a)
+		vt := fmt.Sprintf(f, tm)
+		if a.Err != nil || tm.Err != nil {
+			t.Fatal(f, a.Err, tm.Err)
+		}
+		a.Angle = unit.AngleFromDeg(123.456)
+		tm.Time = unit.TimeFromHour(123.456)
+		oa := fmt.Sprintf(f, a)
+		ot := fmt.Sprintf(f, tm)
+		if !errors.Is(a.Err, sexa.ErrDegreeOverflow) {
+			t.Error(f, "Angle error", a.Err)
+		}
+		if !errors.Is(tm.Err, sexa.ErrHourOverflow) {
+			t.Error(f, "Time error", tm.Err)
+		}
+		if !sexa.IsOverflowOutput(oa, nil) || visWidth(oa) != visWidth(va) {
+			t.Errorf("%s: Angle overflow %q, valid %q", f, oa, va)
+		}
+		if !sexa.IsOverflowOutput(ot, nil) || visWidth(ot) != visWidth(vt) {
+			t.Errorf("%s: Time overflow %q, valid %q", f, ot, vt)
+		}
+	}
+}
+
+// The width counts digits, so multi-byte sign and unit symbols do not
+// cause overflow.
+func TestFixedWidthMultiByte(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits: sexa.UnitSymbols{"deg", "min", "sec"},
+		DecSep:   ".",
+		NegSign:  "−",
+	}
+	a := sym.FmtAngle(unit.NewAngle('-', 123, 4, 5.6))
+	for _, tc := range []struct{ f, want string }{
+		{"%3.1s", "−123deg 4min 5.6sec"},
+		{"%3.1h", "−123.1deg"},
+		{"%4.1h", " −123.1deg"},
+		{"%3.1m", "−123deg 4.1min"},
+	} {
+		if got := fmt.Sprintf(tc.f, a); got != tc.want || a.Err != nil {
+			t.Errorf("%s: got %q, %v want %q", tc.f, got, a.Err, tc.want)
+		}
+	}
+	// overflow fills the width the value would have had, counted in runes
+	got := fmt.Sprintf("%2.1s", a)
+	if !errors.Is(a.Err, sexa.ErrDegreeOverflow) ||
+		got != strings.Repeat("*", 18) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+}
+
+func ExampleSymbols_padSexagesimal() {
+	sym := &sexa.Symbols{
+		DMSUnits:       sexa.UnitSymbols{"°", "′", "″"},
+		DecSep:         ".",
+		PadSexagesimal: true,
+	}
+	a := sym.FmtAngle(unit.NewAngle(' ', 2, 4, 5.6))
+	fmt.Printf("%.1s  %.1m  %3.1s\n", a, a, a)
+	// Output:
+	// 2°04′05.6″  2°04.1′     2°04′05.6″
+}
+
+func TestPadSexagesimal(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits:       sexa.UnitSymbols{"d", "m", "s"},
+		HMSUnits:       sexa.UnitSymbols{"h", "m", "s"},
+		DecSep:         ".",
+		DayUnit:        "d",
+		PadSexagesimal: true,
+	}
+	a := sym.FmtAngle(unit.NewAngle('-', 0, 0, 5))
+	tm := sym.FmtTime(unit.NewTime(' ', 26, 3, 0))
+	for _, tc := range []struct {
+		f    string
+		v    interface{}
+		want string
+	}{
+		{"%s", a, "-5s"}, // leading zero segments are still elided
+		{"%#s", a, "-0d00m05s"},
+		{"%.1m", a, "-0.1m"},
+		{"%s", tm, "1d02h03m00s"},
+		{"%h", tm, "26h"}, // single segment formats are unaffected
+		{"%2s", a, "- 0d00m05s"},
+		{"%02s", a, "-00d00m05s"},
+	} {
+		if got := fmt.Sprintf(tc.f, tc.v); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+}
+
+// At precision 0 the combined and inserted verbs match the following verbs.
+func TestPrecZeroCombineInsert(t *testing.T) {
+	trim := sexa.Default.Clone()
+	trim.TrimTrailingZeros = true
+	double := sexa.Default.Clone()
+	double.DoubleUnit = true
+	for _, sym := range []*sexa.Symbols{nil, sexa.ASCII, trim, double} {
+		for _, x := range []float64{0, 1.5, -12.3456, 359.9999} {
+			vs := []fmt.Formatter{
+				sym.FmtAngle(unit.AngleFromDeg(x)),
+				sym.FmtHourAngle(unit.HourAngleFromHour(x)),
+				sym.FmtRA(unit.RAFromHour(x)),
+				sym.FmtTime(unit.TimeFromHour(x)),
+			}
+			for _, v := range vs {
+				for _, vb := range []string{"sdc", "mon", "hji"} {
+					for _, f := range []string{"%", "%+", "%3", "%-03"} {
+						want := fmt.Sprintf(f+vb[:1], v)
+						for _, c := range vb[1:] {
+							if got := fmt.Sprintf(f+string(c), v); got != want {
+								t.Errorf("%s%c %v: got %q want %q",
+									f, c, x, got, want)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	// likewise with all decimal places trimmed
+	for _, x := range []float64{0, 12, -3} {
+		a := trim.FmtAngle(unit.AngleFromDeg(x))
+		want := fmt.Sprintf("%.2s", a)
+		for _, f := range []string{"%.2c", "%.2d"} {
+			if got := fmt.Sprintf(f, a); got != want {
+				t.Errorf("%s %v: got %q want %q", f, x, got, want)
+			}
+		}
+	}
+}
+
+func TestDoubleUnit(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits:   sexa.UnitSymbols{"°", "′", "″"},
+		DecSep:     ".",
+		DecCombine: '\u0323',
+		DoubleUnit: true,
+	}
+	a := sym.FmtAngle(unit.NewAngle(' ', 12, 34, 45.6))
+	for _, tc := range []struct{ f, want string }{
+		{"%.1s", "12°34′45.6″"},
+		{"%.1c", "12°34′45″̣6″"},
+		{"%.1d", "12°34′45″.6″"},
+		{"%d", "12°34′46″"}, // no decimal separator, no repeat
+		{"%.2o", "12°34′.76′"},
+		{"%.3j", "12°.579°"},
+		{"%.3i", "12°̣579°"},
+	} {
+		if got := fmt.Sprintf(tc.f, a); got != tc.want {
+			t.Errorf("%s: got %s want %s", tc.f, got, tc.want)
+		}
+	}
+	// fixed width overflow fills the doubled width
+	got := fmt.Sprintf("%1.1d", a)
+	if got != "************" {
+		t.Error(got)
+	}
+	for _, d := range []string{"12°.579°", "12°̣579°", "12.579°"} {
+		if s, ok := sym.StripUnit(d, "°"); s != "12.579" || !ok {
+			t.Error(d, s, ok)
+		}
+	}
+}
+
+// Negative values with elided leading segments keep the sign in front of
+// the first displayed segment.
+func TestNegativeElided(t *testing.T) {
+	for _, tc := range []struct {
+		a    unit.Angle
+		f    string
+		want string
+	}{
+		{unit.NewAngle('-', 0, 1, 2.5), "%.1s", "-1′2.5″"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%.1c", "-1′2″̣5"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%.1d", "-1′2″.5"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%+.1s", "-1′2.5″"},
+		{unit.NewAngle('-', 0, 1, 2.5), "% .1s", "-1′2.5″"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%0.1s", "-1′02.5″"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%#.1s", "-0°1′2.5″"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%.2m", "-1.04′"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%.2n", "-1′̣04"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%.2o", "-1′.04"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%.3h", "-0.017°"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%.3i", "-0°̣017"},
+		{unit.NewAngle('-', 0, 1, 2.5), "%.3j", "-0°.017"},
+		{unit.NewAngle('-', 0, 0, 2.5), "%.1s", "-2.5″"},
+		{unit.NewAngle('-', 0, 0, 2.5), "%.1c", "-2″̣5"},
+		{unit.NewAngle('-', 0, 0, 2.5), "%.1d", "-2″.5"},
+		{unit.NewAngle('-', 0, 0, 2.5), "%0.1s", "-2.5″"},
+		{unit.NewAngle('-', 0, 0, 2.5), "%.2m", "-0.04′"},
+		{unit.NewAngle('-', 0, 0, 2.5), "%.2n", "-0′̣04"},
+		{unit.NewAngle('-', 0, 0, 2.5), "%s", "-3″"},
+		{unit.NewAngle('-', 0, 0, 2.5), "%c", "-3″"},
+		// the same zero integer place with sign flags on positive values
+		{unit.NewAngle(' ', 0, 1, 2.5), "%+.3h", "+0.017°"},
+		{unit.NewAngle(' ', 0, 1, 2.5), "% .3h", " 0.017°"},
+		{unit.NewAngle(' ', 0, 1, 2.5), "%+.3i", "+0°̣017"},
+	} {
+		if got := fmt.Sprintf(tc.f, sexa.FmtAngle(tc.a)); got != tc.want {
+			t.Errorf("%s: got %s want %s", tc.f, got, tc.want)
+		}
+	}
+}
+
+// Elision of leading segments depends on the rounded value, not on whether
+// the value is exactly zero.
+func TestElideRounded(t *testing.T) {
+	for _, tc := range []struct {
+		a    unit.Angle
+		f    string
+		want string
+	}{
+		{unit.AngleFromDeg(.0001), "%s", "0″"},
+		{unit.AngleFromDeg(.0001), "%.1s", "0.4″"},
+		{unit.AngleFromDeg(.0001), "%.1m", "0.0′"},
+		{unit.AngleFromDeg(-.0001), "%.1s", "-0.4″"},
+		{unit.NewAngle(' ', 0, 0, 59.7), "%s", "1′0″"},
+		{unit.NewAngle(' ', 0, 59, 59.7), "%s", "1°0′0″"},
+		{unit.NewAngle(' ', 0, 59, 59.7), "%.1s", "59′59.7″"},
+		{unit.NewAngle(' ', 0, 59, 59.7), "%m", "1°0′"},
+		{unit.AngleFromDeg(.0001), "%#s", "0°0′0″"},
+	} {
+		if got := fmt.Sprintf(tc.f, sexa.FmtAngle(tc.a)); got != tc.want {
+			t.Errorf("%v %s: got %s want %s", tc.a.Deg(), tc.f, got, tc.want)
+		}
+	}
+}
+
+func ExampleSymbols_minusSign() {
+	// U+2212 MINUS SIGN, as typeset for publication
+	sym := sexa.DefaultSymbols()
+	sym.NegSign = "\u2212"
+	fmt.Println(sym.FmtAngle(unit.NewAngle('-', 12, 34, 45)))
+	// Output:
+	// −12°34′45″
+}
+
+// Without SignPad, the elided sign is padded to the width of the signs.
+func TestSignPadDefault(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.NegSign = "\u2212"
+	p := sym.FmtAngle(unit.NewAngle(' ', 1, 2, 3))
+	if got := fmt.Sprintf("% s", p); got != " 1°2′3″" {
+		t.Errorf("got %q", got)
+	}
+	sym.NegSign, sym.PosSign = "S ", "N "
+	n := sym.FmtAngle(unit.NewAngle('-', 1, 2, 3))
+	for _, f := range []string{"% s", "%2s", "% .1h"} {
+		if pos, neg := fmt.Sprintf(f, p), fmt.Sprintf(f, n); len(pos) != len(neg) {
+			t.Errorf("%s: %q misaligned with %q", f, pos, neg)
+		}
+	}
+	if got := fmt.Sprintf("%2s", p); got != "   1° 2′ 3″" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestSigns(t *testing.T) {
+	// full width signs for alignment with CJK text
+	sym := &sexa.Symbols{
+		DMSUnits: sexa.UnitSymbols{"°", "′", "″"},
+		DecSep:   ".",
+		PosSign:  "＋",
+		NegSign:  "－",
+		SignPad:  "\u3000", // ideographic space
+	}
+	p := sym.FmtAngle(unit.NewAngle(' ', 1, 2, 3))
+	n := sym.FmtAngle(unit.NewAngle('-', 1, 2, 3))
+	for _, tc := range []struct {
+		f          string
+		pos, neg   string
+		sameLength bool
+	}{
+		{"%s", "1°2′3″", "－1°2′3″", false},
+		{"%+s", "＋1°2′3″", "－1°2′3″", true},
+		{"% s", "\u30001°2′3″", "－1°2′3″", true},
+		{"%2s", "\u3000 1° 2′ 3″", "－ 1° 2′ 3″", true},
+		{"%.2h", "1.03°", "－1.03°", false},
+		{"%+.2h", "＋1.03°", "－1.03°", true},
+		{"%3.2h", "  \u30001.03°", "  －1.03°", true},
+		{"%03.2h", "\u3000001.03°", "－001.03°", true},
+	} {
+		gp := fmt.Sprintf(tc.f, p)
+		gn := fmt.Sprintf(tc.f, n)
+		if gp != tc.pos || gn != tc.neg {
+			t.Errorf("%s: got %q %q want %q %q", tc.f, gp, gn, tc.pos, tc.neg)
+		}
+		if tc.sameLength && visWidth(gp) != visWidth(gn) {
+			t.Errorf("%s: %q and %q not aligned", tc.f, gp, gn)
+		}
+	}
+	// overflow asterisks account for the multi-byte sign
+	n.Angle = unit.AngleFromDeg(-100)
+	if got := fmt.Sprintf("%2.2h", n); got != "*******" {
+		t.Error(got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.Rounding = sexa.RoundTowardZero
+	for _, tc := range []struct {
+		a       unit.Angle
+		f, want string
+	}{
+		{unit.AngleFromSec(45.678), "%.1s", "45.6″"},
+		{unit.AngleFromSec(-45.678), "%.1s", "-45.6″"},
+		{unit.AngleFromSec(45.7), "%.1s", "45.7″"},
+		{unit.AngleFromSec(-45.7), "%.1s", "-45.7″"},
+		{unit.AngleFromSec(45.69999), "%.1s", "45.6″"},
+		// no carry into minutes or degrees
+		{unit.NewAngle(' ', 12, 59, 59.99), "%.1s", "12°59′59.9″"},
+		{unit.NewAngle('-', 12, 59, 59.99), "%.1s", "-12°59′59.9″"},
+		{unit.NewAngle(' ', 12, 59, 59.99), "%.2m", "12°59.99′"},
+		{unit.NewAngle(' ', 12, 59, 59.99), "%.3h", "12.999°"},
+		{unit.NewAngle('-', 12, 59, 59.99), "%h", "-12°"},
+		// exact digits stay
+		{unit.NewAngle(' ', 13, 0, 0), "%.3h", "13.000°"},
+		{unit.NewAngle(' ', 359, 59, 59.9), "%.1s", "359°59′59.9″"},
+		// negatives truncated to zero show no sign
+		{unit.AngleFromSec(-.09), "%.1s", "0.0″"},
+		{unit.AngleFromSec(-59.9), "%m", "0′"},
+	} {
+		if got := fmt.Sprintf(tc.f, sym.FmtAngle(tc.a)); got != tc.want {
+			t.Errorf("%g″ %s: got %q want %q", tc.a.Sec(), tc.f, got, tc.want)
+		}
+	}
+	// every tenth of a second at precision 1 keeps its digit
+	for k := 0; k < 36000; k++ {
+		s := float64(k) / 10
+		got := fmt.Sprintf("%.1S", sym.FmtAngle(unit.AngleFromSec(s)))
+		if want := fmt.Sprintf("%.1f″", s); got != want {
+			t.Fatalf("got %s want %s", got, want)
+		}
+	}
+}
+
+func TestRounding(t *testing.T) {
+	for _, tc := range []struct {
+		mode sexa.RoundingMode
+		h    float64
+		want string
+	}{
+		{sexa.RoundHalfUp, 2.5, "3ʰ"},
+		{sexa.RoundHalfUp, 3.5, "4ʰ"},
+		{sexa.RoundHalfUp, -2.5, "-3ʰ"},
+		{sexa.RoundHalfEven, 2.5, "2ʰ"},
+		{sexa.RoundHalfEven, 3.5, "4ʰ"},
+		{sexa.RoundHalfEven, -2.5, "-2ʰ"},
+		{sexa.RoundHalfEven, 2.5000001, "3ʰ"},
+		{sexa.RoundTowardZero, 2.5, "2ʰ"},
+		{sexa.RoundTowardZero, 2.9999, "2ʰ"},
+		{sexa.RoundTowardZero, -2.9999, "-2ʰ"},
+	} {
+		sym := &sexa.Symbols{HMSUnits: sexa.UnitSymbols{"ʰ", "ᵐ", "ˢ"},
+			Rounding: tc.mode}
+		got := fmt.Sprintf("%h", sym.FmtTime(unit.TimeFromHour(tc.h)))
+		if got != tc.want {
+			t.Errorf("mode %d, %v: got %s want %s", tc.mode, tc.h, got, tc.want)
+		}
+	}
+	// all segment formats honor the mode
+	sym := &sexa.Symbols{HMSUnits: sexa.UnitSymbols{"h", "m", "s"},
+		DecSep: ".", Rounding: sexa.RoundHalfEven}
+	tm := sym.FmtTime(unit.Time(3600*2 + 60*3 + 4.125))
+	if got := fmt.Sprintf("%.2s", tm); got != "2h3m4.12s" {
+		t.Error(got)
+	}
+	tm.Time = unit.TimeFromHour(.375)
+	if got := fmt.Sprintf("%m", tm); got != "22m" {
+		t.Error(got)
+	}
+	tm.Time = unit.TimeFromHour(2.125)
+	if got := fmt.Sprintf("%.2h", tm); got != "2.12h" {
+		t.Error(got)
+	}
+	sym.Rounding = sexa.RoundTowardZero
+	tm.Time = unit.Time(59.99)
+	if got := fmt.Sprintf("%.1s", tm); got != "59.9s" {
+		t.Error(got)
+	}
+}
+
+// Rounding up carries into higher segments and never leaves a 60.
+func TestCarry(t *testing.T) {
+	for _, tc := range []struct {
+		f    string
+		a    unit.Angle
+		want string
+	}{
+		{"%.1s", unit.NewAngle(' ', 0, 0, 59.96), "1′0.0″"},
+		{"%.2s", unit.NewAngle(' ', 0, 0, 59.999), "1′0.00″"},
+		{"%.1s", unit.NewAngle(' ', 0, 59, 59.96), "1°0′0.0″"},
+		{"%.1s", unit.NewAngle('-', 1, 59, 59.999), "-2°0′0.0″"},
+		{"%.1m", unit.NewAngle(' ', 0, 59, 59.94), "1°0.0′"},
+		{"%.2m", unit.NewAngle(' ', 1, 59, 59.94), "2°0.00′"},
+		{"%.1c", unit.NewAngle(' ', 0, 59, 59.96), "1°0′0″̣0"},
+		{"%02.1s", unit.NewAngle(' ', 9, 59, 59.96), " 10°00′00.0″"},
+		{"%.2h", unit.NewAngle(' ', 9, 59, 59.999), "10.00°"},
+		// carry overflows the width
+		{"%1.1s", unit.NewAngle(' ', 9, 59, 59.96), "***********"},
+		{"%1.1m", unit.NewAngle(' ', 9, 59, 59.94), "********"},
+		{"%1.1h", unit.NewAngle(' ', 9, 59, 59.999), "*****"},
+	} {
+		a := sexa.FmtAngle(tc.a)
+		if got := fmt.Sprintf(tc.f, a); got != tc.want {
+			t.Errorf("%s: got %s want %s", tc.f, got, tc.want)
+		}
+	}
+	tm := sexa.FmtTime(unit.NewTime(' ', 23, 59, 59.96))
+	if got := fmt.Sprintf("%.1s", tm); got != "24ʰ0ᵐ0.0ˢ" {
+		t.Error(got)
+	}
+	tm.Time = unit.NewTime(' ', 23, 59, 59.94)
+	if got := fmt.Sprintf("%.1m", tm); got != "24ʰ0.0ᵐ" {
+		t.Error(got)
+	}
+	tm.Time = unit.NewTime(' ', 99, 59, 59.96)
+	if got := fmt.Sprintf("%2.1s", tm); !errors.Is(tm.Err, sexa.ErrHourOverflow) {
+		t.Error(got, tm.Err)
+	}
+}
+
+func TestTrimTrailingZeros(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits:          sexa.UnitSymbols{"°", "′", "″"},
+		DecSep:            ".",
+		DecCombine:        '̣',
+		TrimTrailingZeros: true,
+	}
+	a := sym.FmtAngle(unit.NewAngle(' ', 12, 34, 45.6))
+	for _, tc := range []struct{ f, want string }{
+		{"%.2s", "12°34′45.6″"},
+		{"%.2c", "12°34′45″̣6"},
+		{"%.2d", "12°34′45″.6"},
+		{"%#.2s", "12°34′45.60″"},
+		{"%.0s", "12°34′46″"},
+		{"%.2m", "12°34.76′"},
+	} {
+		if got := fmt.Sprintf(tc.f, a); got != tc.want {
+			t.Errorf("%s: got %s want %s", tc.f, got, tc.want)
+		}
+	}
+	// separator removed with the fraction
+	a.Angle = unit.NewAngle(' ', 12, 34, 45)
+	for _, tc := range []struct{ f, want string }{
+		{"%.3s", "12°34′45″"},
+		{"%.3c", "12°34′45″"},
+		{"%.3d", "12°34′45″"},
+		{"%#.1c", "12°34′45″̣0"},
+		{"%.3h", "12.579°"},
+	} {
+		if got := fmt.Sprintf(tc.f, a); got != tc.want {
+			t.Errorf("%s: got %s want %s", tc.f, got, tc.want)
+		}
+	}
+	a.Angle = unit.AngleFromDeg(12.5)
+	sym.DoubleUnit = true
+	for _, tc := range []struct{ f, want string }{
+		{"%.4i", "12°̣5°"},
+		{"%.4h", "12.5°"},
+		{"%.4s", "12°30′0″"},
+	} {
+		if got := fmt.Sprintf(tc.f, a); got != tc.want {
+			t.Errorf("%s: got %s want %s", tc.f, got, tc.want)
+		}
+	}
+}
+
+func ExampleAngle_compact() {
+	for _, a := range []unit.Angle{
+		unit.NewAngle(' ', 12, 0, 0),
+		unit.NewAngle(' ', 12, 30, 0),
+		unit.NewAngle(' ', 12, 30, 15),
+		unit.NewAngle(' ', 12, 29, 59.99),
+	} {
+		fmt.Printf("%.1g  %g  %#g\n",
+			sexa.FmtAngleASCII(a), sexa.FmtAngleASCII(a), sexa.FmtAngleASCII(a))
+	}
+	// Output:
+	// 12.0d  12d  12d0m0s
+	// 12d30.0m  12d30m  12d30m0s
+	// 12d30m15.0s  12d30m15s  12d30m15s
+	// 12d30.0m  12d30m  12d30m0s
+}
+
+func TestCompact(t *testing.T) {
+	a := sexa.FmtAngleASCII(unit.Angle(math.Inf(1)))
+	if got := fmt.Sprintf("%.1g", a); got != "****" || !errors.Is(a.Err, sexa.ErrPosInf) {
+		t.Error(got, a.Err)
+	}
+	a.Angle = unit.AngleFromSec(5e12 + 1./1024)
+	if got := fmt.Sprintf("%.3g", a); !errors.Is(a.Err, sexa.ErrLossOfPrecision) {
+		t.Error(got, a.Err)
+	}
+	a.Angle = unit.AngleFromDeg(-1.5)
+	if got := fmt.Sprintf("%+3g", a); got != "-  1d30m" {
+		t.Error(got)
+	}
+}
+
+// The space flag holds the sign column, so results align with negative
+// values, by digit count without a width, or all together with one.
+func TestSpaceFlagAlign(t *testing.T) {
+	for _, ds := range [][]float64{
+		{1.02, -1.02, 9.9994, -0},
+		{12.345, -12.345, 10, -99.9},
+		{123.4, -123.4, 100, -359.9999},
+	} {
+		var want int
+		for i, d := range ds {
+			got := fmt.Sprintf("% .3h", sexa.FmtAngle(unit.AngleFromDeg(d)))
+			if d < 0 && got[0] != '-' || d >= 0 && got[0] != ' ' {
+				t.Errorf("%g: sign column %q", d, got)
+			}
+			if p := strings.Index(got, "."); i == 0 {
+				want = p
+			} else if p != want {
+				t.Errorf("%g: %q misaligned", d, got)
+			}
+		}
+	}
+	var want string
+	for i, d := range []float64{1.02, -1.02, 12.345, -12.345, 123.4, -123.4} {
+		got := fmt.Sprintf("% 3.3h", sexa.FmtAngle(unit.AngleFromDeg(d)))
+		if i == 0 {
+			want = got
+		} else if len(got) != len(want) ||
+			strings.Index(got, ".") != strings.Index(want, ".") {
+			t.Errorf("%g: %q misaligned with %q", d, got, want)
+		}
+	}
+}
+
+func TestLeftJustify(t *testing.T) {
+	a := sexa.FmtAngleASCII(unit.NewAngle('-', 1, 2, 3))
+	for _, tc := range []struct{ f, want string }{
+		{"%3s", "-  1d 2m 3s"},
+		{"%-3s", "-1d 2m 3s  "},
+		{"%-+3m", "-1d 2m  "},
+		{"%-3.1h", "-1.0d  "},
+		{"%3.1h", "  -1.0d"},
+		{"%-03s", "-1d02m03s  "}, // - overrides 0 for the first segment
+		{"%-s", "-1d2m3s"},
+	} {
+		if got := fmt.Sprintf(tc.f, a); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	a.Angle = unit.AngleFromDeg(12.5)
+	if got := fmt.Sprintf("%-3.1h|", a); got != " 12.5d |" {
+		t.Errorf("got %q", got)
+	}
+	// overflow fills the same width as the justified value
+	for _, f := range []string{"%-1s", "%-1.2h"} {
+		a.Angle = unit.AngleFromDeg(0)
+		w := len(fmt.Sprintf(f, a))
+		a.Angle = unit.AngleFromDeg(100)
+		if got := fmt.Sprintf(f, a); got != strings.Repeat("*", w) {
+			t.Errorf("%s: got %q want %d asterisks", f, got, w)
+		}
+	}
+}
+
+// As with package fmt, the '-' flag takes precedence over '0'.
+func TestMinusOverridesZero(t *testing.T) {
+	for _, tc := range []struct {
+		f    string
+		a    unit.Angle
+		want string
+	}{
+		{"%-08.2s|", unit.NewAngle(' ', 12, 3, 4.5), " 12d03m04.50s      |"},
+		{"%-08.2s|", unit.NewAngle('-', 12, 3, 4.5), "-12d03m04.50s      |"},
+		{"%-8.2s|", unit.NewAngle(' ', 12, 3, 4.5), " 12d 3m 4.50s      |"},
+		{"%-8.2s|", unit.NewAngle('-', 12, 3, 4.5), "-12d 3m 4.50s      |"},
+		{"%08.2s|", unit.NewAngle('-', 12, 3, 4.5), "-00000012d03m04.50s|"},
+		{"%-08.2h|", unit.NewAngle('-', 12, 30, 0), "-12.50d      |"},
+		{"%08.2h|", unit.NewAngle('-', 12, 30, 0), "-00000012.50d|"},
+	} {
+		if got := fmt.Sprintf(tc.f, sexa.FmtAngleASCII(tc.a)); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+}
+
+func ExampleSymbols_totalWidth() {
+	sym := &sexa.Symbols{
+		DMSUnits:   sexa.UnitSymbols{"°", "′", "″"},
+		DecSep:     ".",
+		TotalWidth: true,
+	}
+	for _, a := range []unit.Angle{
+		unit.NewAngle(' ', 12, 34, 45.6),
+		unit.NewAngle('-', 1, 2, 3),
+		unit.NewAngle(' ', 12345678, 0, 0),
+	} {
+		fmt.Printf("|%14.1s|%-14.1s|\n", sym.FmtAngle(a), sym.FmtAngle(a))
+	}
+	// Output:
+	// |   12°34′45.6″|12°34′45.6″   |
+	// |     -1°2′3.0″|-1°2′3.0″     |
+	// |**************|**************|
+}
+
+func TestTotalWidth(t *testing.T) {
+	sym := &sexa.Symbols{
+		HMSUnits:   sexa.UnitSymbols{"ʰ", "ᵐ", "ˢ"},
+		DecSep:     ".",
+		DecCombine: '̣',
+		TotalWidth: true,
+	}
+	ra := sym.FmtRA(unit.NewRA(1, 2, 3.4))
+	// the combining mark does not count
+	if got := fmt.Sprintf("%10.1c", ra); got != "   1ʰ2ᵐ3ˢ̣4" || ra.Err != nil {
+		t.Errorf("got %q, %v", got, ra.Err)
+	}
+	if got := fmt.Sprintf("%6.1s", ra); got != "******" ||
+		!errors.Is(ra.Err, sexa.ErrHourOverflow) {
+		t.Errorf("got %q, %v", got, ra.Err)
+	}
+	a := sym.FmtAngle(unit.Angle(math.NaN()))
+	if got := fmt.Sprintf("%5s", a); got != "*****" || !errors.Is(a.Err, sexa.ErrNaN) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+	if got := fmt.Sprintf("%5x", a); got != "%!x(BADVERB)" {
+		t.Errorf("got %q", got)
+	}
+	// without a width, TotalWidth has no effect
+	if got := fmt.Sprintf("%.1s", ra); got != "1ʰ2ᵐ3.4ˢ" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func ExampleSymbols_dayUnit() {
+	sym := &sexa.Symbols{
+		HMSUnits: sexa.UnitSymbols{"ʰ", "ᵐ", "ˢ"},
+		DecSep:   ".",
+		DayUnit:  "ᵈ",
+	}
+	t := sym.FmtTime(unit.TimeFromHour(50))
+	fmt.Printf("%s  %.1m  %.1h  %2s  %02s\n", t, t, t, t, t)
+	t.Time = unit.TimeFromHour(5.5)
+	fmt.Printf("%s  %#s  %2s\n", t, t, t)
+	// Output:
+	// 2ᵈ2ʰ0ᵐ0ˢ  2ᵈ2ʰ0.0ᵐ  50.0ʰ    2ᵈ 2ʰ 0ᵐ 0ˢ   02ᵈ02ʰ00ᵐ00ˢ
+	// 5ʰ30ᵐ0ˢ  0ᵈ5ʰ30ᵐ0ˢ    0ᵈ 5ʰ30ᵐ 0ˢ
+}
+
+func TestDayUnit(t *testing.T) {
+	sym := &sexa.Symbols{
+		HMSUnits: sexa.UnitSymbols{"h", "m", "s"},
+		DayUnit:  "d",
+	}
+	tm := sym.FmtTime(unit.TimeFromHour(-24 * 123))
+	if got := fmt.Sprintf("%2s", tm); !errors.Is(tm.Err, sexa.ErrHourOverflow) {
+		t.Error(got, tm.Err)
+	}
+	if got := fmt.Sprintf("%3s", tm); got != "-123d 0h 0m 0s" {
+		t.Error(got)
+	}
+	// other types are not affected
+	sym.DMSUnits = sexa.UnitSymbols{"d", "m", "s"}
+	if got := fmt.Sprint(sym.FmtAngle(unit.AngleFromDeg(50))); got != "50d0m0s" {
+		t.Error(got)
+	}
+}
+
+func TestTotalSec(t *testing.T) {
+	a := sexa.FmtAngleASCII(unit.NewAngle('-', 0, 0, 1.5))
+	for _, tc := range []struct{ f, want string }{
+		{"%S", "-2s"},
+		{"%+.2S", "-1.50s"},
+		{"%6.1S", "     -1.5s"},
+		{"%06.1S", "-000001.5s"},
+		{"%.3M", "-0.025m"},
+		{"%2M", "  0m"},
+	} {
+		if got := fmt.Sprintf(tc.f, a); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	a.Angle = unit.AngleFromSec(5e12 + 1./1024)
+	if got := fmt.Sprintf("%.3S", a); !errors.Is(a.Err, sexa.ErrLossOfPrecision) {
+		t.Error(got, a.Err)
+	}
+	a.Angle = unit.AngleFromDeg(1)
+	if got := fmt.Sprintf("%3S", a); got != "*****" || !errors.Is(a.Err, sexa.ErrDegreeOverflow) {
+		t.Error(got, a.Err)
+	}
+}
+
+func TestOverflowText(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits:     sexa.UnitSymbols{"°", "′", "″"},
+		DecSep:       ".",
+		OverflowText: "OVR",
+	}
+	a := sym.FmtAngle(unit.Angle(math.Inf(1)))
+	zero := sym.FmtAngle(0)
+	for _, tc := range []struct{ f, want string }{
+		{"%s", "OVR"},
+		{"%.3s", "OVR"},
+		{"%0.0h", "OVR"},
+		// fixed width: the width of the value 0
+		{"%2s", "       OVR"},
+		{"%-2m", "OVR    "},
+		{"%3.1h", "    OVR"},
+	} {
+		got := fmt.Sprintf(tc.f, a)
+		if got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+		if !sexa.IsOverflowOutput(got, sym) {
+			t.Errorf("%q not recognized", got)
+		}
+		if _, fixed := map[string]bool{"%2s": true, "%-2m": true,
+			"%3.1h": true}[tc.f]; fixed {
+			if w := len([]rune(fmt.Sprintf(tc.f, zero))); len(got) != w {
+				t.Errorf("%s: width %d want %d", tc.f, len(got), w)
+			}
+		}
+	}
+	// truncated to a narrow fixed width
+	sym.OverflowText = "overflow"
+	a.Angle = unit.AngleFromDeg(1e3)
+	if got := fmt.Sprintf("%1h", a); got != "ove" || !errors.Is(a.Err, sexa.ErrDegreeOverflow) ||
+		!sexa.IsOverflowOutput(got, sym) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+	// total width
+	sym.TotalWidth = true
+	a.Angle = unit.Angle(math.NaN())
+	if got := fmt.Sprintf("%12s|%-10s|%4s", a, a, a); got != "    overflow|overflow  |over" {
+		t.Errorf("got %q", got)
+	}
+	if sexa.IsOverflowOutput("", sym) || sexa.IsOverflowOutput("OVR", sym) {
+		t.Error("false positive")
+	}
+}
+
+func TestNaNText(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.NaNText = "NaN"
+	sym.PosInfText = "+Inf"
+	sym.NegInfText = "-Inf"
+	for _, tc := range []struct {
+		x       float64
+		f, want string
+		err     error
+	}{
+		{math.NaN(), "%s", "NaN", sexa.ErrNaN},
+		{math.Inf(1), "%.1s", "+Inf", sexa.ErrPosInf},
+		{math.Inf(-1), "%h", "-Inf", sexa.ErrNegInf},
+		{math.NaN(), "%3s", "        NaN", sexa.ErrNaN},
+		{math.Inf(-1), "%-3.1m", "-Inf      ", sexa.ErrNegInf},
+		{math.NaN(), "%1h", "NaN", sexa.ErrNaN},
+		{math.Inf(1), "%1h", "+In", sexa.ErrPosInf},
+		// other errors still overflow
+		{1e4, "%1h", "***", sexa.ErrDegreeOverflow},
+	} {
+		a := sym.FmtAngle(unit.AngleFromDeg(tc.x))
+		if got := fmt.Sprintf(tc.f, a); got != tc.want || !errors.Is(a.Err, tc.err) {
+			t.Errorf("%g %s: got %q, %v want %q", tc.x, tc.f, got, a.Err, tc.want)
+		}
+	}
+	// Latitude limits infinities too
+	lat := sym.FmtLatitude(unit.Angle(math.Inf(-1)))
+	if got := lat.String(); got != "-Inf" || !errors.Is(lat.Err, sexa.ErrNegInf) {
+		t.Errorf("got %q, %v", got, lat.Err)
+	}
+}
+
+func ExampleFormatError() {
+	a := sexa.FmtAngle(unit.AngleFromDeg(4423))
+	s := fmt.Sprintf("%3s", a)
+	fmt.Println(s)
+	fmt.Println(a.Err)
+	fmt.Println(errors.Is(a.Err, sexa.ErrDegreeOverflow))
+	var fe *sexa.FormatError
+	if errors.As(a.Err, &fe) {
+		fmt.Println(fe.Value, fe.Width, fe.Prec)
+	}
+	// Output:
+	// ***********
+	// Formatting 4423 in width 3: Degrees overflow width
+	// true
+	// 4423 3 0
+}
+
+func TestSetDefaultSymbols(t *testing.T) {
+	d := sexa.DefaultSymbols()
+	defer sexa.SetDefaultSymbols(sexa.Default)
+	d.DMSUnits = sexa.UnitSymbols{"d", "m", "s"}
+	if d == sexa.Default || sexa.Default.DMSUnits.HrDeg != "°" {
+		t.Fatal("DefaultSymbols did not copy")
+	}
+	c := d.Clone()
+	c.DecSep = ","
+	if d.DecSep != "." {
+		t.Fatal("Clone did not copy")
+	}
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			_ = sexa.FmtAngle(unit.AngleFromDeg(1.5)).String()
+		}
+		done <- true
+	}()
+	sexa.SetDefaultSymbols(d)
+	<-done
+	if got := fmt.Sprint(sexa.FmtAngle(unit.AngleFromDeg(1.5))); got != "1d30m0s" {
+		t.Fatal(got)
+	}
+}
+
+func ExampleASCII() {
+	a := sexa.ASCII.FmtAngle(unit.NewAngle('-', 12, 34, 45.6))
+	fmt.Printf("%.1s  %.1c  %.1d  %.2h\n", a, a, a, a)
+	h := sexa.ASCII.FmtHourAngle(unit.NewHourAngle(' ', 1, 2, 3))
+	fmt.Printf("%s  %#02s\n", h, h)
+	// Output:
+	// -12d34m45.6s  -12d34m45s.6  -12d34m45s.6  -12.58d
+	// 1h2m3s   01h02m03s
+}
+
+func TestNewSymbols(t *testing.T) {
+	dms := sexa.UnitSymbols{"°", "′", "″"}
+	hms := sexa.UnitSymbols{"ʰ", "ᵐ", "ˢ"}
+	sym, err := sexa.NewSymbols(dms, hms, ".", '\u0323')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sym, sexa.Default) {
+		t.Fatal(sym)
+	}
+	if _, err = sexa.NewSymbols(dms, hms, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		decSep  string
+		combine rune
+	}{
+		{".", '.'},      // not a mark
+		{".", '\u20dd'}, // enclosing mark, Me
+		{"", '\u0323'},  // nothing to combine
+	} {
+		if _, err = sexa.NewSymbols(dms, hms, tc.decSep, tc.combine); err == nil {
+			t.Errorf("%q %U accepted", tc.decSep, tc.combine)
+		}
+	}
+	for _, dms := range []sexa.UnitSymbols{
+		{"°", "′", `"`},
+		{"°", "'", "″"},
+	} {
+		if _, err = sexa.NewSymbols(dms, hms, ".", 0); err == nil {
+			t.Errorf("%q accepted", dms)
+		}
+	}
+	if _, err = sexa.NewSymbols(sexa.AsciiQuotes(), hms, ".", 0); err != nil {
+		t.Error(err)
+	}
+}
+
+func ExampleAsciiQuotes() {
+	sym, err := sexa.NewSymbols(sexa.AsciiQuotes(), sexa.ASCII.HMSUnits, ".", 0)
+	fmt.Println(err)
+	fmt.Printf("%.1s\n", sym.FmtAngle(unit.NewAngle(' ', 12, 34, 56.7)))
+	sym, _ = sexa.NewSymbols(sexa.UnicodePrimes(), sexa.ASCII.HMSUnits, ".", 0)
+	fmt.Printf("%.1s\n", sym.FmtAngle(unit.NewAngle(' ', 12, 34, 56.7)))
+	// Output:
+	// <nil>
+	// 12d34'56.7"
+	// 12°34′56.7″
+}
+
+func ExampleSymbols_padRune() {
+	sym := sexa.DefaultSymbols()
+	sym.PadRune = '.'
+	a := sym.FmtAngle(unit.NewAngle(' ', 3, 4, 5.6))
+	fmt.Printf("[%4.1s]  [%-4.1s]  [%4.2h]\n", a, a, a)
+	// Output:
+	// [ ...3° 4′ 5.6″]  [ 3° 4′ 5.6″...]  [ ...3.07°]
+}
+
+func TestPadRune(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.PadRune = '\u2007' // figure space
+	a := sym.FmtAngle(unit.NewAngle('-', 3, 4, 5.6))
+	if got := fmt.Sprintf("%3.1s", a); got != "-\u2007\u20073° 4′ 5.6″" {
+		t.Errorf("got %q", got)
+	}
+	// '0' flag still pads with zeros
+	if got := fmt.Sprintf("%03.1s", a); got != "-003°04′05.6″" {
+		t.Errorf("got %q", got)
+	}
+	// decimal formats place the sign column as sexagesimal formats do
+	dots := sexa.DefaultSymbols()
+	dots.PadRune = '.'
+	for _, tc := range []struct {
+		d       float64
+		f, want string
+	}{
+		{12.5764, "%4.2h", " ..12.58°"},
+		{-12.5764, "%4.2h", "-..12.58°"},
+		{-12.5764, "%4.1s", "-..12°34′35.0″"},
+		{-12.5764, "%+4.2i", "-..12°̣58"},
+		{12.5764, "%+4.2j", "+..12°.58"},
+		{-12.5764, "%-4.2h|", "-12.58°..|"},
+	} {
+		if got := fmt.Sprintf(tc.f, dots.FmtAngle(unit.AngleFromDeg(tc.d))); got != tc.want {
+			t.Errorf("%s %g: got %q want %q", tc.f, tc.d, got, tc.want)
+		}
+	}
+	// overflow matches the width in runes
+	for _, f := range []string{"%3.1s", "%-3.1s", "%3m", "%4.2h"} {
+		a.Err = nil
+		a.Angle = 0
+		w := utf8.RuneCountInString(strings.Replace(fmt.Sprintf(f, a), "\u0323", "", 1))
+		a.Angle = unit.AngleFromDeg(1e4)
+		got := fmt.Sprintf(f, a)
+		if !errors.Is(a.Err, sexa.ErrDegreeOverflow) || utf8.RuneCountInString(got) != w {
+			t.Errorf("%s: got %q, %v want width %d", f, got, a.Err, w)
+		}
+	}
+}
+
+func TestNegativeZero(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	tiny := unit.AngleFromSec(-.0001)
+	for _, tc := range []struct {
+		a       unit.Angle
+		f, want string
+	}{
+		{unit.Angle(negZero), "%s", "0″"},
+		{unit.Angle(negZero), "%.1h", "0.0°"},
+		{unit.Angle(negZero), "%+m", "+0′"},
+		{tiny, "%s", "0″"},
+		{tiny, "%.0s", "0″"},
+		{tiny, "%m", "0′"},
+		{tiny, "%h", "0°"},
+		{tiny, "%#s", "0°0′0″"},
+		{tiny, "%2s", "  0° 0′ 0″"},
+		{tiny, "%.4s", "-0.0001″"},
+		{unit.AngleFromSec(-.4), "%+S", "+0″"},
+	} {
+		if got := fmt.Sprintf(tc.f, sexa.FmtAngle(tc.a)); got != tc.want {
+			t.Errorf("%g %s: got %q want %q", tc.a.Sec(), tc.f, got, tc.want)
+		}
+	}
+	for _, a := range []unit.Angle{unit.Angle(negZero), tiny} {
+		if got := sexa.FmtLatitude(a).String(); got != "0″N" {
+			t.Errorf("%g: got %q", a.Sec(), got)
+		}
+	}
+}
+
+func ExampleSymbols_highPrecision() {
+	sym := sexa.DefaultSymbols()
+	sym.HighPrecision = true
+	a := sym.FmtAngle(unit.AngleFromSec(-1.25e-10))
+	fmt.Printf("%.20s\n", a)
+	fmt.Printf("%.18c\n", a)
+	a.Angle = unit.AngleFromSec(1)
+	fmt.Printf("%.16s\n", a)
+	fmt.Println(a.Err)
+	// Output:
+	// -0.00000000012500000000″
+	// -0″̣000000000125000000
+	// *******************
+	// Formatting 0.0002777777777777778 at precision 16: Loss of precision
+}
+
+func TestHighPrecision(t *testing.T) {
+	a := sexa.FmtAngle(unit.AngleFromSec(1e-10))
+	if got := fmt.Sprintf("%.16s", a); got != "%!(BADPREC 16)" || a.Err != nil {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+	sym := sexa.DefaultSymbols()
+	sym.HighPrecision = true
+	a.Sym = sym
+	if got := fmt.Sprintf("%.41s", a); got != "%!(BADPREC 41)" {
+		t.Errorf("got %q", got)
+	}
+	// agrees with precision 15 at the boundary
+	x := unit.AngleFromSec(.0123456789012345)
+	a.Angle = x
+	s15 := fmt.Sprintf("%.15s", a)
+	if got := fmt.Sprintf("%.16s", a); got[:14] != s15[:14] {
+		t.Errorf("got %q, %q at 15", got, s15)
+	}
+	for _, tc := range []struct {
+		a       unit.Angle
+		f, want string
+	}{
+		{0, "%.16s", "0.0000000000000000″"},
+		{0, "%#.16s", "0°0′0.0000000000000000″"},
+		{0, "%.16g", "0.0000000000000000°"},
+		{unit.AngleFromSec(1e-20), "%.20g", "0.00000000000000000001″"},
+		{unit.AngleFromSec(1e-20), "%.19s", "0.0000000000000000000″"},
+		{unit.AngleFromSec(-1e-20), "%.19s", "0.0000000000000000000″"},
+		{unit.AngleFromSec(1.5e-17), "%.16s", "0.0000000000000000″"},
+		{unit.AngleFromSec(-1e-17), "%2.17s", "- 0° 0′ 0.00000000000000001″"},
+		{unit.AngleFromDeg(1e-17), "%.17h", "0.00000000000000001°"},
+		{unit.AngleFromDeg(1e-17), "%.19S", "0.0000000000000360000″"},
+	} {
+		a.Angle = tc.a
+		if got := fmt.Sprintf(tc.f, a); got != tc.want || a.Err != nil {
+			t.Errorf("%g %s: got %q, %v want %q", tc.a.Sec(), tc.f, got, a.Err, tc.want)
+		}
+	}
+	sym.TrimTrailingZeros = true
+	a.Angle = unit.AngleFromSec(1.25e-10)
+	if got := fmt.Sprintf("%.20s", a); got != "0.000000000125″" {
+		t.Errorf("got %q", got)
+	}
+	sym.Rounding = sexa.RoundTowardZero
+	a.Angle = unit.AngleFromSec(1.9e-17)
+	if got := fmt.Sprintf("%.17s", a); got != "0.00000000000000001″" {
+		t.Errorf("got %q", got)
+	}
+	// losing significance
+	a.Angle = unit.AngleFromSec(1)
+	if got := fmt.Sprintf("%.16s", a); !errors.Is(a.Err, sexa.ErrLossOfPrecision) ||
+		!sexa.IsOverflowOutput(got, sym) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+}
+
+func TestSigExact(t *testing.T) {
+	for _, tc := range []struct {
+		f, want string
+		v       sexa.SexaFormatter
+	}{
+		{"%.10s", "135°0′0.0000000000″", sexa.FmtAngle(unit.NewAngle(' ', 135, 0, 0))},
+		{"%.12s", "359°59′59.000000000000″", sexa.FmtAngle(unit.NewAngle(' ', 359, 59, 59))},
+		{"%.11s", "-359°59′45.50000000000″", sexa.FmtAngle(unit.NewAngle('-', 359, 59, 45.5))},
+		{"%.13s", "23ʰ59ᵐ59.0000000000000ˢ", sexa.FmtHourAngle(unit.NewHourAngle(' ', 23, 59, 59))},
+		{"%.14h", "90.00000000000000°", sexa.FmtAngle(unit.AngleFromDeg(90))},
+		{"%.14S", "3600.00000000000000″", sexa.FmtAngle(unit.AngleFromDeg(1))},
+	} {
+		got := fmt.Sprintf(tc.f, tc.v)
+		if got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	// not exact, so limited to the significance of the float64
+	a := sexa.FmtAngle(unit.NewAngle(' ', 359, 59, 59.1))
+	if got := fmt.Sprintf("%.12s", a); !errors.Is(a.Err, sexa.ErrLossOfPrecision) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+	// exact, but too many digits for int64
+	a.Angle = unit.AngleFromDeg(1e10)
+	if got := fmt.Sprintf("%.15s", a); !errors.Is(a.Err, sexa.ErrLossOfPrecision) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+}
+
+func ExampleSymbols_segSep() {
+	a := sexa.FmtAngle(unit.NewAngle(' ', 12, 34, 45.6))
+	fmt.Printf("%.1u\n", a)
+	ra := sexa.FmtRA(unit.NewRA(1, 2, 3))
+	fmt.Printf("%u|%02u\n", ra, ra)
+	sym := sexa.DefaultSymbols()
+	sym.SegSep = ":"
+	fmt.Printf("%.2u\n", sym.FmtHourAngle(unit.NewHourAngle('-', 0, 0, 5)))
+	// Output:
+	// 12 34 45.6
+	// 1 2 3| 01 02 03
+	// -0:0:5.00
+}
+
+func TestUnitless(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.DayUnit = "ᵈ"
+	for _, tc := range []struct {
+		v       sexa.SexaFormatter
+		f, want string
+	}{
+		{sexa.FmtAngle(unit.NewAngle('-', 1, 2, 3.45)), "%.1u", "-1 2 3.5"},
+		{sexa.FmtAngle(unit.NewAngle('-', 1, 2, 3.45)), "%3.1u", "-  1  2  3.5"},
+		{sexa.FmtAngle(unit.NewAngle('-', 1, 2, 3.45)), "%-3.1u", "-1  2  3.5  "},
+		{sexa.FmtAngle(unit.NewAngle(' ', 0, 0, 59.96)), "%.1u", "0 1 0.0"},
+		{sexa.FmtAngle(unit.NewAngle(' ', 0, 0, 5)), "%+u", "+0 0 5"},
+		{sexa.FmtLatitude(unit.NewAngle('-', 1, 2, 3)), "%u", "1 2 3S"},
+		// no days segment
+		{sym.FmtTime(unit.NewTime(' ', 25, 0, 0)), "%u", "25 0 0"},
+		{sym.FmtTime(unit.NewTime(' ', 25, 0, 0)), "%s", "1ᵈ1ʰ0ᵐ0ˢ"},
+	} {
+		if got := fmt.Sprintf(tc.f, tc.v); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	// overflow fills the width of the format
+	a := sexa.FmtAngle(unit.AngleFromDeg(1000))
+	if got := fmt.Sprintf("%2.1u", a); got != "***********" ||
+		!errors.Is(a.Err, sexa.ErrDegreeOverflow) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+}
+
+func ExampleSymbols_groupSep() {
+	sym := sexa.DefaultSymbols()
+	sym.GroupSep = ","
+	a := sym.FmtAngle(unit.AngleFromDeg(360))
+	fmt.Printf("%S  %.1S  %M\n", a, a, a)
+	// Output:
+	// 1,296,000″  1,296,000.0″  21,600′
+}
+
+func TestGroupSep(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.GroupSep = ","
+	for _, tc := range []struct {
+		x       float64
+		f, want string
+	}{
+		{360, "%S", "1,296,000″"},
+		{-360, "%S", "-1,296,000″"},
+		{.1, "%S", "360″"},
+		{1, "%S", "3,600″"},
+		{12345.5, "%.1h", "12,345.5°"},
+		{12345, "%s", "12,345°0′0″"},
+		// minutes and seconds never group
+		{12345 + 59.5/60, "%.3m", "12,345°59.500′"},
+		// a width counts digits, padded to the grouped width
+		{1, "%7S", "     3,600″"},
+		{360, "%7S", " 1,296,000″"},
+		{-1, "%7S", "    -3,600″"},
+		{1, "%-7S|", " 3,600″    |"},
+		{1, "%07S", " 0,003,600″"},
+		{-1, "%07.1S", "-0,003,600.0″"},
+		{12345, "%6s", "  12,345° 0′ 0″"},
+		{12345, "%06s", " 012,345°00′00″"},
+		{12345, "%-6s|", " 12,345° 0′ 0″ |"},
+		{1, "%6s", "       1° 0′ 0″"},
+	} {
+		if got := fmt.Sprintf(tc.f, sym.FmtAngle(unit.AngleFromDeg(tc.x))); got != tc.want {
+			t.Errorf("%g %s: got %q want %q", tc.x, tc.f, got, tc.want)
+		}
+	}
+	// overflow is by digits, filling the grouped width
+	a := sym.FmtAngle(unit.AngleFromDeg(360))
+	if got := fmt.Sprintf("%6S", a); got != "*********" ||
+		!errors.Is(a.Err, sexa.ErrDegreeOverflow) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+	// with fixed width, results align
+	var w int
+	for i, x := range []float64{0, 1, 10, 100, 360} {
+		got := fmt.Sprintf("%7S", sym.FmtAngle(unit.AngleFromDeg(x)))
+		if n := utf8.RuneCountInString(got); i == 0 {
+			w = n
+		} else if n != w {
+			t.Errorf("%q width %d want %d", got, n, w)
+		}
+	}
+}
+
+func ExampleAngle_RoundToSec() {
+	a := sexa.FmtAngle(unit.NewAngle('-', 12, 34, 45.678))
+	r := a.RoundToSec()
+	fmt.Printf("%.3s %.3s\n", a, sexa.FmtAngle(r))
+	fmt.Printf("%.3s\n", sexa.FmtAngle(a.RoundToMin()))
+	fmt.Printf("%.3s\n", sexa.FmtAngle(a.RoundTo(unit.AngleFromSec(.1))))
+	// Output:
+	// -12°34′45.678″ -12°34′46.000″
+	// -12°35′0.000″
+	// -12°34′45.700″
+}
+
+func TestRoundTo(t *testing.T) {
+	// rounded values agree with formatted values, by each mode
+	for _, mode := range []sexa.RoundingMode{sexa.RoundHalfUp,
+		sexa.RoundHalfEven, sexa.RoundTowardZero} {
+		sym := sexa.DefaultSymbols()
+		sym.Rounding = mode
+		for _, s := range []float64{0, .5, 1.5, 2.5, -2.5, 59.5, 45.678,
+			-45.678, 3599.5, 1296000 - .5} {
+			a := sym.FmtAngle(unit.AngleFromSec(s))
+			want := fmt.Sprintf("%s", a)
+			if got := fmt.Sprintf("%s", sym.FmtAngle(a.RoundToSec())); got != want {
+				t.Errorf("mode %d, %g″: RoundToSec %s want %s", mode, s, got, want)
+			}
+			want = fmt.Sprintf("%m", a)
+			if got := fmt.Sprintf("%m", sym.FmtAngle(a.RoundToMin())); got != want {
+				t.Errorf("mode %d, %g″: RoundToMin %s want %s", mode, s, got, want)
+			}
+		}
+	}
+	a := sexa.FmtAngle(unit.AngleFromDeg(12.34))
+	for _, tc := range []struct{ q, want unit.Angle }{
+		{unit.AngleFromDeg(1), unit.AngleFromDeg(12)},
+		{unit.AngleFromDeg(.5), unit.AngleFromDeg(12.5)},
+		{unit.AngleFromDeg(5), unit.AngleFromDeg(10)},
+		{unit.AngleFromDeg(-5), unit.AngleFromDeg(10)},
+		{0, a.Angle},
+	} {
+		if got := a.RoundTo(tc.q); math.Abs(float64(got-tc.want)) > 1e-15 {
+			t.Errorf("%g°: got %g° want %g°", tc.q.Deg(), got.Deg(), tc.want.Deg())
+		}
+	}
+	for _, x := range []float64{math.Inf(1), math.Inf(-1)} {
+		a.Angle = unit.Angle(x)
+		if got := a.RoundToSec(); got != a.Angle {
+			t.Errorf("got %g", got)
+		}
+	}
+	a.Angle = unit.Angle(math.NaN())
+	if got := a.RoundToMin(); !math.IsNaN(float64(got)) {
+		t.Errorf("got %g", got)
+	}
+}
+
+func ExampleSymbols_elideZeroSeconds() {
+	sym := sexa.DefaultSymbols()
+	sym.ElideZeroSeconds = true
+	a := sym.FmtAngle(unit.NewAngle(' ', 12, 34, 0))
+	fmt.Printf("%s  %#s  %.1s  %2s\n", a, a, a, a)
+	// Output:
+	// 12°34′  12°34′  12°34′0.0″   12°34′ 0″
+}
+
+func TestElideZeroSeconds(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.ElideZeroSeconds = true
+	for _, tc := range []struct {
+		a       unit.Angle
+		f, want string
+	}{
+		{unit.NewAngle(' ', 12, 0, 0), "%s", "12°0′"},
+		{unit.NewAngle('-', 12, 34, .2), "%s", "-12°34′"},
+		{unit.NewAngle(' ', 12, 34, 59.7), "%s", "12°35′"},
+		{unit.NewAngle(' ', 12, 34, 1), "%s", "12°34′1″"},
+		{unit.NewAngle(' ', 0, 34, 0), "%s", "34′"},
+		{unit.NewAngle(' ', 0, 34, 0), "%#s", "0°34′"},
+		{unit.NewAngle(' ', 0, 34, 0), "%0s", "34′"},
+		{0, "%s", "0″"},
+		{0, "%#s", "0°0′"},
+		{unit.NewAngle(' ', 12, 34, 0), "%c", "12°34′"},
+		{unit.NewAngle(' ', 12, 34, 0), "%.2c", "12°34′0″̣00"},
+		{unit.NewAngle(' ', 12, 34, 0), "%u", "12 34 0"},
+		// other formats are unaffected
+		{unit.NewAngle(' ', 12, 34, 0), "%m", "12°34′"},
+		{unit.NewAngle(' ', 12, 34, 0), "%S", "45240″"},
+	} {
+		if got := fmt.Sprintf(tc.f, sym.FmtAngle(tc.a)); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	lat := sym.FmtLatitude(unit.NewAngle('-', 12, 34, 0))
+	if got := lat.String(); got != "12°34′S" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func ExampleFmtDuration() {
+	t := sexa.FmtDuration(90*time.Minute + 1500*time.Millisecond)
+	fmt.Printf("%.1s\n", t)
+	fmt.Println(t.Duration())
+	// Output:
+	// 1ʰ30ᵐ1.5ˢ
+	// 1h30m1.5s
+}
+
+func TestDuration(t *testing.T) {
+	for _, d := range []time.Duration{
+		0,
+		1,
+		-1,
+		time.Second - 1,
+		-36*time.Hour - 59*time.Second - 999999999,
+		1<<22*time.Second - 1,
+	} {
+		if got := sexa.FmtDuration(d).Duration(); got != d {
+			t.Errorf("%d: got %d", d, got)
+		}
+	}
+	for _, tc := range []struct {
+		t    unit.Time
+		want time.Duration
+	}{
+		{1.4e-9, 1},
+		{-1.6e-9, -2},
+		{1e10, math.MaxInt64},
+		{-1e10, math.MinInt64},
+		{unit.Time(math.Inf(1)), math.MaxInt64},
+		{unit.Time(math.NaN()), 0},
+	} {
+		if got := sexa.FmtTime(tc.t).Duration(); got != tc.want {
+			t.Errorf("%g: got %d want %d", tc.t, got, tc.want)
+		}
+	}
+}
+
+func ExampleAngle_decimalValue() {
+	a := sexa.FmtAngle(unit.AngleFromDeg(12.5793333))
+	fmt.Printf("%.1r\n", a)
+	fmt.Printf("%r\n", sexa.FmtLatitude(unit.NewAngle('-', 33, 52, 4)))
+	// Output:
+	// 12°34′45.6″ (12.579333°)
+	// 33°52′4″S (33.86778°S)
+}
+
+func TestDecimalValue(t *testing.T) {
+	for _, tc := range []struct {
+		f    string
+		v    fmt.Formatter
+		want string
+	}{
+		{"%r", sexa.FmtTime(unit.NewTime(' ', 1, 30, 0)), "1ʰ30ᵐ0ˢ (1.50000ʰ)"},
+		{"%+.1r", sexa.FmtAngle(unit.AngleFromDeg(-1.5)), "-1°30′0.0″ (-1.500000°)"},
+		{"%+r", sexa.FmtAngle(unit.AngleFromDeg(1.5)), "+1°30′0″ (+1.50000°)"},
+		{"%#04r|", sexa.FmtAngle(unit.AngleFromDeg(1.5)), " 0001°30′00″ (1.50000°)|"},
+		{"%.12r", sexa.FmtAngle(unit.AngleFromDeg(1.5)),
+			"1°30′0.000000000000″ (1.500000000000000°)"},
+	} {
+		if got := fmt.Sprintf(tc.f, tc.v); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	a := sexa.FmtAngle(unit.Angle(math.NaN()))
+	if got := fmt.Sprintf("%r", a); !sexa.IsOverflowOutput(got, nil) ||
+		!errors.Is(a.Err, sexa.ErrNaN) {
+		t.Errorf("NaN: got %q, %v", got, a.Err)
+	}
+}
+
+func ExampleSymbols_negParens() {
+	sym := sexa.DefaultSymbols()
+	sym.NegParens = true
+	for _, d := range []float64{12.5, -12.5, -3.25} {
+		fmt.Printf("|%3s|\n", sym.FmtAngle(unit.AngleFromDeg(d)))
+	}
+	// Output:
+	// |  12°30′ 0″ |
+	// |( 12°30′ 0″)|
+	// |(  3°15′ 0″)|
+}
+
+func TestNegParens(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.NegParens = true
+	for _, tc := range []struct {
+		f    string
+		d    float64
+		want string
+	}{
+		{"%s", -1.5, "(1°30′0″)"},
+		{"%s", 1.5, "1°30′0″"},
+		{"%+s", 1.5, "+1°30′0″"},
+		{"%+s", -1.5, "(1°30′0″)"},
+		{"% s", 1.5, " 1°30′0″ "},
+		{"%.1h", -1.5, "(1.5°)"},
+		{"%03s", -1.5, "(001°30′00″)"},
+		{"%-3s|", -1.5, "(1°30′ 0″)  |"},
+		{"%-3s|", 1.5, " 1°30′ 0″   |"},
+		{"%#s", -1e-6, "0°0′0″"}, // rounds to zero, no parentheses
+	} {
+		if got := fmt.Sprintf(tc.f, sym.FmtAngle(unit.AngleFromDeg(tc.d))); got != tc.want {
+			t.Errorf("%s %g: got %q want %q", tc.f, tc.d, got, tc.want)
+		}
+	}
+	// overflow covers the parentheses
+	a := sym.FmtAngle(unit.AngleFromDeg(-1000))
+	if got := fmt.Sprintf("%2s", a); got != "***********" ||
+		!errors.Is(a.Err, sexa.ErrDegreeOverflow) {
+		t.Errorf("overflow: got %q, %v", got, a.Err)
+	}
+	// custom symbols
+	sym.NegParenOpen, sym.NegParenClose = "[", "]"
+	if got := sym.FmtAngle(unit.AngleFromDeg(-1.5)).String(); got != "[1°30′0″]" {
+		t.Errorf("custom: got %q", got)
+	}
+	// hemisphere indicators take precedence
+	if got := sym.FmtLatitude(unit.AngleFromDeg(-1.5)).String(); got != "1°30′0″S" {
+		t.Errorf("latitude: got %q", got)
+	}
+}
+
+func ExampleAngle_FormattedWidth() {
+	a := sexa.FmtAngle(unit.NewAngle(' ', 12, 34, 45.6))
+	w, _ := a.FormattedWidth('c', 1)
+	fmt.Printf("%.1c %d\n", a, w)
+	// Output:
+	// 12°34′45″̣6 10
+}
+
+func TestFormattedWidth(t *testing.T) {
+	for _, tc := range []struct {
+		a    unit.Angle
+		verb rune
+		prec int
+		want int
+		err  error
+	}{
+		{unit.NewAngle('-', 1, 2, 3), 's', 0, 7, nil},
+		{unit.NewAngle(' ', 1, 2, 3), 'd', 2, 9, nil},
+		{unit.NewAngle(' ', 1, 2, 3), 'i', 3, 5, nil}, // combining mark
+		{unit.Angle(math.NaN()), 's', 1, 0, sexa.ErrNaN},
+		{unit.AngleFromSec(5e12 + 1./1024), 's', 3, 0, sexa.ErrLossOfPrecision},
+	} {
+		a := sexa.FmtAngle(tc.a)
+		got, err := a.FormattedWidth(tc.verb, tc.prec)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%c %d: got error %v want %v", tc.verb, tc.prec, err, tc.err)
+		}
+		if err != nil {
+			// the width of the overflow output
+			s, _ := sexa.FormatAngle(tc.a, tc.verb, tc.prec, nil)
+			tc.want = utf8.RuneCountInString(s)
+		}
+		if got != tc.want {
+			t.Errorf("%c %d: got width %d want %d", tc.verb, tc.prec, got, tc.want)
+		}
+	}
+	// not the width of "%!(BADPREC 16)" or "%!q(BADVERB)"
+	a := sexa.FmtAngle(unit.AngleFromDeg(12.5))
+	if got, err := a.FormattedWidth('s', 16); got != 0 ||
+		!errors.Is(err, sexa.ErrBadPrec) {
+		t.Errorf("s 16: got %d, %v", got, err)
+	}
+	if got, err := a.FormattedWidth('q', 0); got != 0 ||
+		!errors.Is(err, sexa.ErrBadVerb) {
+		t.Errorf("q 0: got %d, %v", got, err)
+	}
+}
+
+func ExampleSymbols_trailingSign() {
+	sym := sexa.DefaultSymbols()
+	sym.TrailingSign = true
+	for _, d := range []float64{12.579, -12.579, -3.25} {
+		fmt.Printf("|%3s|\n", sym.FmtAngle(unit.AngleFromDeg(d)))
+	}
+	// Output:
+	// | 12°34′44″ |
+	// | 12°34′44″-|
+	// |  3°15′ 0″-|
+}
+
+func TestTrailingSign(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.TrailingSign = true
+	for _, tc := range []struct {
+		f    string
+		d    float64
+		want string
+	}{
+		{"%s", -1.5, "1°30′0″-"},
+		{"%s", 1.5, "1°30′0″"},
+		{"%+s", 1.5, "1°30′0″+"},
+		{"% s", 1.5, "1°30′0″ "},
+		{"%.1h", -1.5, "1.5°-"},
+		{"%+2.1h", -1.5, " 1.5°-"},
+		{"%03s", -1.5, "001°30′00″-"},
+		{"%-3s|", -1.5, "1°30′ 0″-  |"},
+		{"%s", -1e-6, "0″"}, // rounds to zero, no sign
+		{"%r", -1.5, "1°30′0″- (1.50000°-)"},
+	} {
+		if got := fmt.Sprintf(tc.f, sym.FmtAngle(unit.AngleFromDeg(tc.d))); got != tc.want {
+			t.Errorf("%s %g: got %q want %q", tc.f, tc.d, got, tc.want)
+		}
+	}
+	// overflow covers the sign column
+	a := sym.FmtAngle(unit.AngleFromDeg(-1000))
+	if got := fmt.Sprintf("%2s", a); got != "**********" ||
+		!errors.Is(a.Err, sexa.ErrDegreeOverflow) {
+		t.Errorf("overflow: got %q, %v", got, a.Err)
+	}
+	// hemisphere indicators and NegParens take precedence
+	if got := sym.FmtLatitude(unit.AngleFromDeg(-1.5)).String(); got != "1°30′0″S" {
+		t.Errorf("latitude: got %q", got)
+	}
+	sym.NegParens = true
+	if got := sym.FmtAngle(unit.AngleFromDeg(-1.5)).String(); got != "(1°30′0″)" {
+		t.Errorf("NegParens: got %q", got)
+	}
+}
+
+func ExampleAngle_Overflows() {
+	a := sexa.FmtAngle(unit.AngleFromDeg(123.4))
+	for w := 2; w <= 3; w++ {
+		if over, _ := a.Overflows('s', 0, w); !over {
+			fmt.Printf("width %d: %*s\n", w, w, a)
+			break
+		}
+		fmt.Printf("width %d overflows\n", w)
+	}
+	// Output:
+	// width 2 overflows
+	// width 3:  123°24′ 0″
+}
+
+func TestOverflows(t *testing.T) {
+	for _, tc := range []struct {
+		a     unit.Angle
+		verb  rune
+		prec  int
+		width int
+		err   error
+	}{
+		{unit.AngleFromDeg(12), 's', 0, 2, nil},
+		{unit.AngleFromDeg(-12), 'h', 3, 2, nil},
+		{unit.AngleFromDeg(123), 's', 0, 0, nil},
+		{unit.AngleFromDeg(123), 's', 0, 2, sexa.ErrDegreeOverflow},
+		{unit.AngleFromDeg(123), 'j', 2, 2, sexa.ErrDegreeOverflow},
+		{unit.AngleFromSec(5e12 + 1./1024), 's', 3, 0, sexa.ErrLossOfPrecision},
+		{unit.Angle(math.Inf(-1)), 's', 0, 0, sexa.ErrNegInf},
+	} {
+		a := sexa.FmtAngle(tc.a)
+		over, err := a.Overflows(tc.verb, tc.prec, tc.width)
+		if over != (tc.err != nil) || !errors.Is(err, tc.err) {
+			t.Errorf("%v %c %d %d: got %t, %v want %v",
+				tc.a, tc.verb, tc.prec, tc.width, over, err, tc.err)
+		}
+		if a.Err != nil {
+			t.Errorf("Err modified: %v", a.Err)
+		}
+		// agrees with formatting
+		if tc.width > 0 {
+			_ = fmt.Sprintf("%*.*"+string(tc.verb), tc.width, tc.prec, a)
+		} else {
+			_ = fmt.Sprintf("%.*"+string(tc.verb), tc.prec, a)
+		}
+		if !errors.Is(a.Err, tc.err) {
+			t.Errorf("formatted: got %v want %v", a.Err, tc.err)
+		}
+	}
+}
+
+func TestOverflowsBadFormat(t *testing.T) {
+	a := sexa.FmtAngle(unit.AngleFromDeg(12.5))
+	for _, tc := range []struct {
+		verb rune
+		prec int
+		err  error
+	}{
+		{'s', 16, sexa.ErrBadPrec},
+		{'s', -1, sexa.ErrBadPrec},
+		{'q', 0, sexa.ErrBadVerb},
+	} {
+		over, err := a.Overflows(tc.verb, tc.prec, 0)
+		var fe *sexa.FormatError
+		if over || !errors.Is(err, tc.err) || !errors.As(err, &fe) {
+			t.Errorf("%c %d: got %t, %v want %v", tc.verb, tc.prec, over, err,
+				tc.err)
+		}
+	}
+	if over, err := a.Overflows('k', 16, 0); over || err != nil {
+		t.Errorf("k 16: got %t, %v", over, err)
+	}
+	if a.Err != nil {
+		t.Errorf("Err modified: %v", a.Err)
+	}
+}
+
+func ExampleAngle_integerKey() {
+	for _, d := range []float64{37.2, 5, 359.6} {
+		fmt.Printf("file%3k.dat\n", sexa.FmtAngle(unit.AngleFromDeg(d)))
+	}
+	// Output:
+	// file037.dat
+	// file005.dat
+	// file360.dat
+}
+
+func TestIntegerKey(t *testing.T) {
+	for _, tc := range []struct {
+		f    string
+		d    float64
+		want string
+	}{
+		{"%k", 37.2, "37"},
+		{"%3k", 37.2, "037"},
+		{"%03k", 37.2, "037"},
+		{"%.2k", 37.5, "38"},
+		{"%3k", -37.2, "-037"},
+		{"%+3k", 37.2, "+037"},
+		{"%-3k|", 37.2, "37 |"},
+		{"%3k", -0.2, "000"},
+		{"%2k", 123, "**"},
+	} {
+		if got := fmt.Sprintf(tc.f, sexa.FmtAngle(unit.AngleFromDeg(tc.d))); got != tc.want {
+			t.Errorf("%s %g: got %q want %q", tc.f, tc.d, got, tc.want)
+		}
+	}
+	sym := sexa.DefaultSymbols()
+	sym.Rounding = sexa.RoundTowardZero
+	if got := fmt.Sprintf("%2k", sym.FmtTime(unit.NewTime(' ', 25, 59, 59))); got != "25" {
+		t.Errorf("got %q", got)
+	}
+	lat := sexa.FmtLatitude(unit.AngleFromDeg(-5))
+	if got := fmt.Sprintf("%2k", lat); got != "05S" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func ExampleAngle_EqualAt() {
+	a := sexa.FmtAngle(unit.NewAngle(' ', 12, 34, 45.61))
+	b := unit.NewAngle(' ', 12, 34, 45.64)
+	fmt.Println(a.EqualAt(b, 1, 's'), a.EqualAt(b, 2, 's'))
+	// Output:
+	// true false
+}
+
+func TestEqualAt(t *testing.T) {
+	for _, tc := range []struct {
+		a, b unit.Angle
+		prec int
+		verb rune
+		want bool
+	}{
+		{unit.AngleFromDeg(1), unit.AngleFromDeg(1 + 1e-15), 14, 'h', true},
+		{unit.AngleFromDeg(1), unit.AngleFromDeg(1.004), 2, 'h', true},
+		{unit.AngleFromDeg(1), unit.AngleFromDeg(1.006), 2, 'h', false},
+		{unit.AngleFromSec(.4), unit.AngleFromSec(-.4), 0, 's', true},
+		{unit.AngleFromSec(59.96), unit.AngleFromSec(60), 1, 's', true},
+		{unit.AngleFromSec(59.96), unit.AngleFromSec(60), 1, 'S', true},
+		{unit.AngleFromSec(59.96), unit.AngleFromSec(60), 2, 'S', false},
+		{unit.Angle(math.NaN()), unit.Angle(math.NaN()), 0, 's', false},
+		{unit.Angle(math.Inf(1)), unit.Angle(math.Inf(1)), 0, 's', false},
+		// invalid formats are not equal, though both give the same text
+		{unit.AngleFromDeg(12.5), unit.AngleFromDeg(99), 16, 'h', false},
+		{unit.AngleFromDeg(12.5), unit.AngleFromDeg(99), -1, 'h', false},
+		{unit.AngleFromDeg(12.5), unit.AngleFromDeg(99), 0, 'q', false},
+		{unit.AngleFromDeg(1), unit.AngleFromDeg(1), 0, 'q', false},
+		// the precision does not apply to %k
+		{unit.AngleFromDeg(12.2), unit.AngleFromDeg(11.9), 16, 'k', true},
+	} {
+		if got := sexa.FmtAngle(tc.a).EqualAt(tc.b, tc.prec, tc.verb); got != tc.want {
+			t.Errorf("%v %v %c %d: got %t", tc.a, tc.b, tc.verb, tc.prec, got)
+		}
+	}
+	// the rounding of the symbols of a applies
+	sym := sexa.DefaultSymbols()
+	sym.Rounding = sexa.RoundTowardZero
+	if sym.FmtAngle(unit.AngleFromSec(59.96)).EqualAt(unit.AngleFromSec(60), 1, 's') {
+		t.Error("RoundTowardZero: equal")
+	}
+}
+
+// Under a width, '#' is implied and no segment is elided, even for values
+// under an arc minute.
+func TestFixedWidthSubArcminute(t *testing.T) {
+	for _, tc := range []struct {
+		f    string
+		a    unit.Angle
+		want string
+	}{
+		{"%2s", unit.AngleFromSec(30), "  0° 0′30″"},
+		{"%2s", unit.AngleFromSec(-30), "- 0° 0′30″"},
+		{"%2.1s", unit.AngleFromSec(.04), "  0° 0′ 0.0″"},
+		{"%2.1s", unit.AngleFromSec(-.04), "  0° 0′ 0.0″"},
+		{"%02s", unit.AngleFromSec(5), " 00°00′05″"},
+		{"%-2s|", unit.AngleFromSec(5), " 0° 0′ 5″ |"},
+		{"%2.1m", unit.AngleFromSec(6), "  0° 0.1′"},
+		{"%2s", unit.AngleFromSec(59.7), "  0° 1′ 0″"},
+		// without a width, leading zero segments are elided
+		{"%s", unit.AngleFromSec(30), "30″"},
+	} {
+		if got := fmt.Sprintf(tc.f, sexa.FmtAngle(tc.a)); got != tc.want {
+			t.Errorf("%s %v: got %q want %q", tc.f, tc.a.Sec(), got, tc.want)
+		}
+	}
+}
+
+func ExampleSymbols_sigFigs() {
+	sym := sexa.DefaultSymbols()
+	sym.SigFigs = 3
+	for _, d := range []float64{12.57, 0.01257} {
+		fmt.Printf("%h\n", sym.FmtAngle(unit.AngleFromDeg(d)))
+	}
+	// Output:
+	// 12.6°
+	// 0.0126°
+}
+
+func TestSigFigs(t *testing.T) {
+	sym := sexa.DefaultSymbols()
+	sym.SigFigs = 4
+	for _, tc := range []struct {
+		f    string
+		a    unit.Angle
+		want string
+	}{
+		{"%h", unit.AngleFromDeg(123.456), "123.5°"},
+		{"%h", unit.AngleFromDeg(-0.5), "-0.5000°"},
+		{"%h", unit.AngleFromDeg(12345), "12345°"},
+		{"%h", 0, "0.000°"},
+		{"%s", unit.NewAngle(' ', 1, 2, 3.45), "1°2′3″"},
+		{"%s", unit.NewAngle(' ', 12, 34, 45.6), "12°34′46″"},
+		{"%s", unit.AngleFromSec(1.23456), "1.235″"},
+		{"%s", unit.AngleFromSec(90.123), "1′30.1″"},
+		{"%#s", unit.AngleFromSec(90.123), "0°1′30.1″"},
+		{"%s", unit.AngleFromSec(9.123), "9.123″"},
+		{"%m", unit.NewAngle(' ', 0, 12, 20), "12.33′"},
+		{"%S", unit.AngleFromSec(12.3456), "12.35″"},
+		{"%M", unit.AngleFromSec(12.3456), "0.2058′"},
+		{"%.1h", unit.AngleFromDeg(123.456), "123.5°"},
+		{"%.0h", unit.AngleFromDeg(1.23456), "1°"}, // explicit precision
+		{"%h", unit.AngleFromDeg(1e-30), "0.000000000000000°"},
+	} {
+		if got := fmt.Sprintf(tc.f, sym.FmtAngle(tc.a)); got != tc.want {
+			t.Errorf("%s %v: got %q want %q", tc.f, tc.a.Deg(), got, tc.want)
+		}
+	}
+}
+
+func ExampleSymbols_revUnit() {
+	sym := sexa.DefaultSymbols()
+	sym.RevUnit = " rev"
+	fmt.Printf("%.6t\n", sym.FmtAngle(unit.AngleFromDeg(12.575)))
+	fmt.Printf("%.3t\n", sexa.FmtRA(unit.NewRA(18, 0, 0)))
+	// Output:
+	// 0.034931 rev
+	// 0.750
+}
+
+func TestTurns(t *testing.T) {
+	for _, tc := range []struct {
+		f    string
+		a    unit.Angle
+		want string
+	}{
+		{"%t", unit.AngleFromDeg(180), "1"},
+		{"%.2t", unit.AngleFromDeg(180), "0.50"},
+		{"%.2t", unit.AngleFromDeg(-90), "-0.25"},
+		{"%.1t", unit.AngleFromDeg(720), "2.0"},
+		{"%2.2t", unit.AngleFromDeg(90), "  0.25"},
+		{"%1.2t", unit.AngleFromDeg(3600), "*****"},
+	} {
+		if got := fmt.Sprintf(tc.f, sexa.FmtAngle(tc.a)); got != tc.want {
+			t.Errorf("%s %v: got %q want %q", tc.f, tc.a.Deg(), got, tc.want)
+		}
+	}
+	a := sexa.FmtAngle(unit.Angle(math.Inf(1)))
+	if got := fmt.Sprintf("%t", a); !sexa.IsOverflowOutput(got, nil) ||
+		!errors.Is(a.Err, sexa.ErrPosInf) {
+		t.Errorf("Inf: got %q, %v", got, a.Err)
+	}
+	a.Angle = unit.AngleFromDeg(1e20)
+	if got := fmt.Sprintf("%.3t", a); !sexa.IsOverflowOutput(got, nil) ||
+		!errors.Is(a.Err, sexa.ErrLossOfPrecision) {
+		t.Errorf("loss: got %q, %v", got, a.Err)
+	}
+}
+
+func ExampleAngle_WrapTo360() {
+	a := sexa.FmtAngle(unit.AngleFromDeg(730))
+	fmt.Println(a, sexa.FmtAngle(a.WrapTo360()))
+	a.Angle = unit.AngleFromDeg(190)
+	fmt.Println(a, sexa.FmtAngle(a.WrapTo180()))
+	// Output:
+	// 730°0′0″ 10°0′0″
+	// 190°0′0″ -170°0′0″
+}
+
+func TestWrap(t *testing.T) {
+	for _, tc := range []struct {
+		d, w360, w180 float64
+	}{
+		{0, 0, 0},
+		{360, 0, 0},
+		{-90, 270, -90},
+		{180, 180, -180},
+		{-180, 180, -180},
+		{540, 180, -180},
+		{-1e-20, 0, -1e-20},
+		{1e-20, 1e-20, 1e-20},
+	} {
+		a := sexa.FmtAngle(unit.AngleFromDeg(tc.d))
+		w360, w180 := a.WrapTo360(), a.WrapTo180()
+		if math.Abs(w360.Deg()-tc.w360) > 1e-12 || w360 < 0 || w360.Deg() >= 360 {
+			t.Errorf("WrapTo360(%g) = %g", tc.d, w360.Deg())
+		}
+		if math.Abs(w180.Deg()-tc.w180) > 1e-12 || w180.Deg() < -180 || w180.Deg() >= 180 {
+			t.Errorf("WrapTo180(%g) = %g", tc.d, w180.Deg())
+		}
+		if a.Angle != unit.AngleFromDeg(tc.d) {
+			t.Errorf("%g: receiver modified", tc.d)
+		}
+	}
+}
+
+func ExampleAngle_DecimalDeg() {
+	lon := sexa.FmtAngle(unit.AngleFromDeg(-122.41942))
+	fmt.Println(lon.DecimalDeg(4))
+	ra := sexa.FmtRA(unit.NewRA(12, 30, 0))
+	fmt.Println(ra.DecimalHour(3))
+	// Output:
+	// -122.4194
+	// 12.500
+}
+
+func TestDecimalDeg(t *testing.T) {
+	sym := &sexa.Symbols{
+		DMSUnits:     sexa.UnitSymbols{"°", "′", "″"},
+		DecSep:       ",",
+		NegSign:      "−",
+		NegParens:    true,
+		TrailingSign: true,
+		GroupSep:     " ",
+		Rounding:     sexa.RoundHalfEven,
+	}
+	a := sym.FmtAngle(unit.AngleFromDeg(-1234.125))
+	if got := a.DecimalDeg(2); got != "-1234.12" || a.Err != nil {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+	// the sign is that of the rounded value
+	a = sexa.FmtAngle(unit.AngleFromDeg(-1e-9))
+	if got := a.DecimalDeg(3); got != "0.000" {
+		t.Errorf("got %q", got)
+	}
+	a = sexa.FmtAngle(unit.Angle(math.NaN()))
+	if got := a.DecimalDeg(1); !errors.Is(a.Err, sexa.ErrNaN) {
+		t.Errorf("got %q, %v", got, a.Err)
+	}
+	a = sexa.FmtAngle(unit.AngleFromDeg(1))
+	for _, prec := range []int{-1, 16} {
+		if got := a.DecimalDeg(prec); got != "" ||
+			!errors.Is(a.Err, sexa.ErrBadPrec) {
+			t.Errorf("prec %d: got %q, %v", prec, got, a.Err)
+		}
+	}
+	h := sexa.FmtHourAngle(unit.HourAngleFromHour(-1.5))
+	if got := h.DecimalHour(16); got != "" || !errors.Is(h.Err, sexa.ErrBadPrec) {
+		t.Errorf("got %q, %v", got, h.Err)
+	}
+	if got := h.DecimalHour(1); got != "-1.5" {
+		t.Errorf("got %q", got)
+	}
+	ra := sexa.FmtRA(unit.RAFromHour(-1))
+	if got := ra.DecimalHour(0); got != "23" {
+		t.Errorf("got %q", got)
+	}
+	tm := sexa.FmtTime(unit.NewTime(' ', 1, 30, 0))
+	if got := tm.DecimalHour(2); got != "1.50" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func ExampleAngle_sharpPoint() {
+	a := sexa.FmtAngle(unit.AngleFromDeg(12.34))
+	fmt.Printf("%#.0h\n%#.1h\n", a, a)
+	// Output:
+	// 12.°
+	// 12.3°
+}
+
+func TestSharpPoint(t *testing.T) {
+	a := sexa.FmtAngle(unit.AngleFromDeg(12.34))
+	for _, tc := range []struct{ f, want string }{
+		{"%.0h", "12°"},
+		{"%#h", "12.°"},
+		{"%#.0i", "12°."}, // nothing to combine with, inserted form
+		{"%#.0j", "12°."},
+		{"%#.1i", "12°̣3"},
+		{"%#4.0h", "   12.°"},
+		{"%#04.0h", " 0012.°"},
+		{"%#-4.0h|", " 12.°  |"},
+		{"%#.0S", "44424.″"},
+		{"%#.0s", "12°20′24″"}, // not a single segment
+		{"%#.0c", "12°20′24″"},
+		{"%#.0d", "12°20′24″"},
+		{"%#.0m", "12°20′"},
+		{"%#.0n", "12°20′"},
+		{"%#.0o", "12°20′"},
+		{"%#3k", "012"},
+	} {
+		if got := fmt.Sprintf(tc.f, a); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.f, got, tc.want)
+		}
+	}
+	sym := sexa.DefaultSymbols()
+	sym.DoubleUnit = true
+	if got := fmt.Sprintf("%#.0j", sym.FmtAngle(a.Angle)); got != "12°.°" {
+		t.Errorf("DoubleUnit: got %q", got)
+	}
+	sym.DecSep = ""
+	if got := fmt.Sprintf("%#.0h", sym.FmtAngle(a.Angle)); got != "12°" {
+		t.Errorf("no DecSep: got %q", got)
+	}
+	c := sexa.FmtClock(unit.NewTime(' ', 1, 30, 0))
+	if got := fmt.Sprintf("%h", c); got != "02" {
+		t.Errorf("Clock: got %q", got)
+	}
+}
+
+func ExampleTime_RoundToMin() {
+	t := sexa.FmtTime(unit.NewTime(' ', 11, 59, 45))
+	fmt.Println(sexa.FmtTime(t.RoundToMin()), sexa.FmtTime(t.RoundToHour()))
+	// Output:
+	// 12ʰ0ᵐ0ˢ 12ʰ0ᵐ0ˢ
+}
+
+func TestTimeRoundTo(t *testing.T) {
+	for _, tc := range []struct {
+		t, min, hr unit.Time
+	}{
+		{unit.NewTime(' ', 1, 2, 29.9), unit.NewTime(' ', 1, 2, 0), unit.NewTime(' ', 1, 0, 0)},
+		{unit.NewTime(' ', 1, 2, 30), unit.NewTime(' ', 1, 3, 0), unit.NewTime(' ', 1, 0, 0)},
+		{unit.NewTime('-', 1, 29, 30), unit.NewTime('-', 1, 30, 0), unit.NewTime('-', 1, 0, 0)},
+		{unit.NewTime('-', 0, 0, 20), 0, 0},
+		{unit.NewTime(' ', 23, 59, 45), unit.NewTime(' ', 24, 0, 0), unit.NewTime(' ', 24, 0, 0)},
+	} {
+		tm := sexa.FmtTime(tc.t)
+		if got := tm.RoundToMin(); got != tc.min {
+			t.Errorf("%v RoundToMin: got %v want %v", tc.t, got, tc.min)
+		}
+		if got := tm.RoundToHour(); got != tc.hr {
+			t.Errorf("%v RoundToHour: got %v want %v", tc.t, got, tc.hr)
+		}
+		// agrees with the formatter
+		if got, want := fmt.Sprintf("%m", sexa.FmtTime(tm.RoundToMin())),
+			fmt.Sprintf("%m", tm); got != want {
+			t.Errorf("%v: rounded %q formatted %q", tc.t, got, want)
+		}
+	}
+	// rounding mode of the symbols
+	sym := sexa.DefaultSymbols()
+	sym.Rounding = sexa.RoundTowardZero
+	if got := sym.FmtTime(unit.NewTime(' ', 1, 59, 59)).RoundToHour(); got != unit.NewTime(' ', 1, 0, 0) {
+		t.Errorf("RoundTowardZero: got %v", got)
+	}
+	// a days segment
+	sym = sexa.DefaultSymbols()
+	sym.DayUnit = "ᵈ"
+	tm := sym.FmtTime(unit.NewTime(' ', 23, 59, 45))
+	if got := sym.FmtTime(tm.RoundToMin()).String(); got != "1ᵈ0ʰ0ᵐ0ˢ" {
+		t.Errorf("days: got %q", got)
+	}
+}
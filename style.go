@@ -0,0 +1,73 @@
+// License: MIT
+
+package sexa
+
+import (
+	"sort"
+	"sync"
+)
+
+// styles holds the symbols registered by name, guarded by stylesMu.
+var (
+	stylesMu sync.RWMutex
+	styles   = map[string]*Symbols{
+		"unicode": {
+			DMSUnits:   UnitSymbols{"°", "′", "″"},
+			HMSUnits:   UnitSymbols{"ʰ", "ᵐ", "ˢ"},
+			DecSep:     ".",
+			DecCombine: '\u0323',
+		},
+		"ascii": ASCII,
+		"colon": {
+			DMSUnits: UnitSymbols{":", ":", ""},
+			HMSUnits: UnitSymbols{":", ":", ""},
+			DecSep:   ".",
+		},
+		"european": {
+			DMSUnits:   UnitSymbols{"°", "′", "″"},
+			HMSUnits:   UnitSymbols{"ʰ", "ᵐ", "ˢ"},
+			DecSep:     ",",
+			DecCombine: '\u0326', // combining comma below
+		},
+	}
+)
+
+// Style returns a copy of the symbols registered as name, and whether there
+// are any, as for selecting an output style from a command line flag.
+//
+// Predefined styles are "unicode", the initial default symbols; "ascii", the
+// symbols of ASCII; "colon", separating segments with colons as in 12:34:45.6;
+// and "european", the unicode symbols with a decimal comma.  Names are case
+// sensitive.  See also RegisterStyle and StyleNames.
+func Style(name string) (*Symbols, bool) {
+	stylesMu.RLock()
+	sym, ok := styles[name]
+	stylesMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return sym.Clone(), true
+}
+
+// RegisterStyle registers sym as the style name, replacing any style of that
+// name, including predefined styles.
+//
+// It is safe to call concurrently with Style.  Sym should not be modified
+// afterward.
+func RegisterStyle(name string, sym *Symbols) {
+	stylesMu.Lock()
+	styles[name] = sym
+	stylesMu.Unlock()
+}
+
+// StyleNames returns the names of registered styles, sorted.
+func StyleNames() []string {
+	stylesMu.RLock()
+	names := make([]string, 0, len(styles))
+	for n := range styles {
+		names = append(names, n)
+	}
+	stylesMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
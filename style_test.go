@@ -0,0 +1,55 @@
+// License: MIT
+
+package sexa_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleStyle() {
+	a := unit.NewAngle(' ', 12, 34, 45.6)
+	for _, name := range []string{"unicode", "ascii", "colon", "european"} {
+		sym, _ := sexa.Style(name)
+		fmt.Printf("%-8s %.1s\n", name, sym.FmtAngle(a))
+	}
+	// Output:
+	// unicode  12°34′45.6″
+	// ascii    12d34m45.6s
+	// colon    12:34:45.6
+	// european 12°34′45,6″
+}
+
+func TestStyle(t *testing.T) {
+	if _, ok := sexa.Style("Unicode"); ok {
+		t.Error("names should be case sensitive")
+	}
+	// Style returns a copy
+	sym, ok := sexa.Style("ascii")
+	if !ok || *sym != *sexa.ASCII || sym == sexa.ASCII {
+		t.Fatal("ascii", sym, ok)
+	}
+	sym.DecSep = ","
+	if sym2, _ := sexa.Style("ascii"); sym2.DecSep != "." {
+		t.Error("registered style modified")
+	}
+	// registered styles
+	spaced := &sexa.Symbols{HMSUnits: sexa.UnitSymbols{"h ", "m ", "s"}, DecSep: "."}
+	sexa.RegisterStyle("spaced", spaced)
+	sym, ok = sexa.Style("spaced")
+	if got := sym.FmtRA(unit.NewRA(1, 2, 3)).String(); !ok || got != "1h 2m 3s" {
+		t.Errorf("spaced: got %q, %t", got, ok)
+	}
+	if sym, ok := sexa.Style("colon"); !ok ||
+		sym.FmtTime(unit.NewTime('-', 1, 2, 3)).String() != "-1:2:3" {
+		t.Error("colon")
+	}
+	names := sexa.StyleNames()
+	if !sort.StringsAreSorted(names) || len(names) < 5 {
+		t.Error(names)
+	}
+}